@@ -1,6 +1,7 @@
 package geomys
 
 import (
+	"fmt"
 	"github.com/reconditematter/mym"
 	"math"
 )
@@ -59,13 +60,64 @@ func (prj Albers) Project(p Point) (xy [2]float64) {
 	sinφ, _ := mym.SinCosD(lat)
 	q := prj.qalb(sinφ)
 	ρ := prj.sph.A() * math.Sqrt(prj.c-prj.n*q) / prj.n
-	θ := prj.n * (lon - prj.λ0)
+	θ := prj.n * angNormalize(lon-prj.λ0)
 	sinθ, cosθ := mym.SinCosD(θ)
 	xy[0] = ρ * sinθ
 	xy[1] = prj.ρ0 - ρ*cosθ
 	return
 }
 
+// Unproject -- transforms a location on the plane into
+// a geographic point on the spheroid. Returns an error wrapping
+// ErrOutOfDomain when `xy` is outside the image of the spheroid.
+func (prj Albers) Unproject(xy [2]float64) (Point, error) {
+	if prj.par == nil {
+		panic("geomys.Albers.Unproject: uninitialized structure")
+	}
+	//
+	a, e2 := prj.sph.A(), prj.sph.E2()
+	x, y := xy[0], prj.ρ0-xy[1]
+	if prj.n < 0 {
+		x, y = -x, -y
+	}
+	ρ2 := mym.Sq(math.Hypot(x, y) * prj.n / a)
+	λ := atan2d(x, y) / prj.n
+	q := (prj.c - ρ2) / prj.n
+	qp := authq(e2, 1)
+	if !(math.Abs(λ) <= 180) {
+		// allow for the round-off in θ/n at the antimeridian
+		if !(math.Abs(λ) <= 180*(1+8*mym.Epsilon/math.Abs(prj.n))) {
+			return Point{}, fmt.Errorf("geomys.Albers.Unproject: %w: `xy`", ErrOutOfDomain)
+		}
+		λ = math.Copysign(180, λ)
+	}
+	if !(math.Abs(q) <= qp) {
+		// allow for the round-off in q near the poles
+		tol := 64 * mym.Epsilon * (math.Abs(prj.c) + ρ2) / math.Abs(prj.n)
+		if !(math.Abs(q) <= qp+tol) {
+			return Point{}, fmt.Errorf("geomys.Albers.Unproject: %w: `xy`", ErrOutOfDomain)
+		}
+		q = math.Copysign(qp, q)
+	}
+	// the authalic latitude is the initial approximation,
+	// it is refined by Newton's method on q(φ)
+	ξ := math.Asin(q/qp) * (180 / math.Pi)
+	φ := prj.sph.GeoLat(AuthalicLat, ξ)
+	for i := 0; i < 8; i++ {
+		sinφ, cosφ := mym.SinCosD(φ)
+		if cosφ == 0 {
+			break
+		}
+		w2 := 1 - e2*sinφ*sinφ
+		dφ := (q - authq(e2, sinφ)) * w2 * w2 / (2 * (1 - e2) * cosφ) * (180 / math.Pi)
+		φ = math.Max(-90, math.Min(90, φ+dφ))
+		if math.Abs(dφ) <= 8*mym.Epsilon*90 {
+			break
+		}
+	}
+	return Geo(φ, angNormalize(prj.λ0+λ), 0.0), nil
+}
+
 func (prj *Albers) inialb() {
 	lat1 := prj.par["lat1"]
 	lat2 := prj.par["lat2"]
@@ -95,7 +147,5 @@ func (prj Albers) malb(sinφ, cosφ float64) float64 {
 }
 
 func (prj Albers) qalb(sinφ float64) float64 {
-	e2 := prj.sph.E2()
-	e := math.Sqrt(e2)
-	return (1 - e2) * (sinφ/(1-e2*sinφ*sinφ) - (1/(2*e))*math.Log((1-e*sinφ)/(1+e*sinφ)))
+	return authq(prj.sph.E2(), sinφ)
 }
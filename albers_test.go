@@ -0,0 +1,78 @@
+package geomys
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestAlbersSnyder(t *testing.T) {
+	// Snyder, J.P. Map Projections: A Working Manual (1987), p.292
+	prj := NewAlbers(Clarke1866(), 29.5, 45.5, 23, -96)
+	xy := prj.Project(Geo(35, -75, 0))
+	if math.Abs(xy[0]-1885472.7) > 0.1 || math.Abs(xy[1]-1535925.0) > 0.1 {
+		t.Errorf("Project: got %v", xy)
+	}
+	p, err := prj.Unproject(xy)
+	if err != nil {
+		t.Fatalf("Unproject: %v", err)
+	}
+	if lat, lon, _ := p.Geo(); math.Abs(lat-35) > 1e-11 || math.Abs(lon+75) > 1e-11 {
+		t.Errorf("Unproject: got (%v,%v)", lat, lon)
+	}
+}
+
+func TestAlbersRoundTrip(t *testing.T) {
+	spheroids := map[string]Spheroid{
+		"Clarke1866":        Clarke1866(),
+		"International1924": International1924(),
+		"WGS1972":           WGS1972(),
+		"GRS1967":           GRS1967(),
+		"GRS1980":           GRS1980(),
+		"WGS1984":           WGS1984(),
+		"IERS2003":          IERS2003(),
+		"SRMmax":            SRMmax(),
+	}
+	params := [][4]float64{
+		{29.5, 45.5, 23, -96},
+		{-18, -36, 0, 132},
+		{50, 70, 40, 180},
+		{-20, 60, 0, 0},
+	}
+	for name, sph := range spheroids {
+		for _, par := range params {
+			prj := NewAlbers(sph, par[0], par[1], par[2], par[3])
+			for lat := -90.0; lat <= 90; lat += 7.5 {
+				for lon := -180.0; lon <= 180; lon += 15 {
+					p, err := prj.Unproject(prj.Project(Geo(lat, lon, 0)))
+					if err != nil {
+						t.Fatalf("%s %v (%v,%v): %v", name, par, lat, lon, err)
+					}
+					lat2, lon2, _ := p.Geo()
+					// the latitude is ill-conditioned at the poles
+					// that are mapped to circular arcs
+					tol := 1e-9
+					if math.Abs(lat) == 90 {
+						tol = 1e-5
+					}
+					if math.Abs(lat2-lat) > tol {
+						t.Errorf("%s %v (%v,%v): lat=%v", name, par, lat, lon, lat2)
+					}
+					dlon, _ := angDiff(lon, lon2)
+					if math.Abs(lat) < 90 && math.Abs(dlon) > 1e-9 {
+						t.Errorf("%s %v (%v,%v): lon=%v", name, par, lat, lon, lon2)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestAlbersOutOfDomain(t *testing.T) {
+	prj := NewAlbers(WGS1984(), 29.5, 45.5, 23, -96)
+	for _, xy := range [][2]float64{{0, 1e8}, {0, -1e8}, {1e8, 0}, {math.NaN(), 0}} {
+		if _, err := prj.Unproject(xy); !errors.Is(err, ErrOutOfDomain) {
+			t.Errorf("Unproject(%v): got %v", xy, err)
+		}
+	}
+}
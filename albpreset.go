@@ -0,0 +1,58 @@
+package geomys
+
+import (
+	"fmt"
+	"sort"
+)
+
+// AlbersPreset -- the Albers conical equal-area map projection of a national or continental grid.
+// The false easting and northing of all the presets are zero.
+type AlbersPreset struct {
+	Name      string   // the short name, for example "conus"
+	Title     string   // the name of the CRS in the registry of the authority
+	Authority string   // "EPSG" or "ESRI" when the CRS is not in the EPSG registry
+	Code      int      // the code of the CRS in the registry of the authority
+	Spheroid  Spheroid // the spheroid of the geodetic datum
+	Lat1      float64  // latitude of the 1st standard parallel
+	Lat2      float64  // latitude of the 2nd standard parallel
+	Lat0      float64  // latitude of the center
+	Lon0      float64  // longitude of the center
+}
+
+// albersPresets -- the catalogue of the presets.
+var albersPresets = []AlbersPreset{
+	{"conus", "NAD83 / Conus Albers", "EPSG", 5070, GRS1980(), 29.5, 45.5, 23, -96},
+	{"alaska", "NAD83 / Alaska Albers", "EPSG", 3338, GRS1980(), 55, 65, 50, -154},
+	{"hawaii", "Hawaii_Albers_Equal_Area_Conic", "ESRI", 102007, GRS1980(), 8, 18, 13, -157},
+	{"canada", "Canada_Albers_Equal_Area_Conic", "ESRI", 102001, GRS1980(), 50, 70, 40, -96},
+	{"north_america", "North_America_Albers_Equal_Area_Conic", "ESRI", 102008, GRS1980(), 20, 60, 40, -96},
+	{"australia", "GDA94 / Australian Albers", "EPSG", 3577, GRS1980(), -18, -36, 0, 132},
+	{"australia2020", "GDA2020 / Australian Albers", "EPSG", 9473, GRS1980(), -18, -36, 0, 132},
+	{"europe", "Europe_Albers_Equal_Area_Conic", "ESRI", 102013, International1924(), 43, 62, 30, 10},
+	{"asia_north", "Asia_North_Albers_Equal_Area_Conic", "ESRI", 102025, WGS1984(), 15, 65, 30, 95},
+	{"asia_south", "Asia_South_Albers_Equal_Area_Conic", "ESRI", 102028, WGS1984(), 7, -32, -15, 125},
+	{"africa", "Africa_Albers_Equal_Area_Conic", "ESRI", 102022, WGS1984(), 20, -23, 0, 25},
+}
+
+// AlbersPresets -- returns the available presets sorted by name.
+func AlbersPresets() []AlbersPreset {
+	presets := append([]AlbersPreset(nil), albersPresets...)
+	sort.Slice(presets, func(i, j int) bool { return presets[i].Name < presets[j].Name })
+	return presets
+}
+
+// NewAlbersPreset -- returns the Albers map projection of the preset `name`.
+// Returns an error wrapping ErrUnknownProjection when there is no such preset.
+func NewAlbersPreset(name string) (Albers, error) {
+	for _, ps := range albersPresets {
+		if ps.Name == name {
+			return ps.Projection(), nil
+		}
+	}
+	return Albers{}, fmt.Errorf("geomys.NewAlbersPreset: %w: `%s`", ErrUnknownProjection, name)
+}
+
+// Projection -- returns the Albers map projection of the preset.
+func (ps AlbersPreset) Projection() Albers {
+	return NewAlbers(ps.Spheroid, ps.Lat1, ps.Lat2, ps.Lat0, ps.Lon0)
+}
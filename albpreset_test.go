@@ -0,0 +1,38 @@
+package geomys
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"testing"
+)
+
+func TestAlbersPresets(t *testing.T) {
+	presets := AlbersPresets()
+	if len(presets) < 8 || !sort.SliceIsSorted(presets, func(i, j int) bool { return presets[i].Name < presets[j].Name }) {
+		t.Fatalf("AlbersPresets: got %v", presets)
+	}
+	for _, ps := range presets {
+		prj, err := NewAlbersPreset(ps.Name)
+		if err != nil || prj.Spheroid() != ps.Spheroid {
+			t.Errorf("%s: got %v, %v", ps.Name, prj, err)
+			continue
+		}
+		// the center is the false origin, the standard parallels are true to scale
+		if xy := prj.Project(Geo(ps.Lat0, ps.Lon0, 0)); math.Abs(xy[0]) > 1e-6 || math.Abs(xy[1]) > 1e-6 {
+			t.Errorf("%s: origin at %v", ps.Name, xy)
+		}
+		for _, lat := range []float64{ps.Lat1, ps.Lat2} {
+			if d := Tissot(prj, Geo(lat, ps.Lon0, 0)); math.Abs(d.K-1) > 1e-7 {
+				t.Errorf("%s: k=%v at %v", ps.Name, d.K, lat)
+			}
+		}
+	}
+	conus, _ := NewAlbersPreset("conus")
+	if want := NewAlbers(GRS1980(), 29.5, 45.5, 23, -96); conus.Project(Geo(40, -75, 0)) != want.Project(Geo(40, -75, 0)) {
+		t.Errorf("conus: got %v", conus.Params())
+	}
+	if _, err := NewAlbersPreset("mars"); !errors.Is(err, ErrUnknownProjection) {
+		t.Errorf("mars: got %v", err)
+	}
+}
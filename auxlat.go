@@ -0,0 +1,173 @@
+package geomys
+
+import (
+	"github.com/reconditematter/mym"
+	"math"
+)
+
+// AuxLatitude -- identifies an auxiliary latitude of a spheroid.
+type AuxLatitude int
+
+const (
+	GeographicLat AuxLatitude = iota // geographic (geodetic) latitude φ
+	ParametricLat                    // parametric (reduced) latitude β
+	GeocentricLat                    // geocentric latitude θ
+	RectifyingLat                    // rectifying latitude μ
+	ConformalLat                     // conformal latitude χ
+	AuthalicLat                      // authalic latitude ξ
+)
+
+// AuxLat -- converts the geographic latitude `φ` (degrees) to the auxiliary latitude `aux` (degrees).
+// This function causes a runtime panic when φ∉[-90,90].
+//
+// The conversions are Fourier series in the third flattening n of `s`:
+//
+//	ζ = φ + ∑ C[k](n)⋅sin(2kφ),  k=1,...,6,
+//
+// where C[k] is O(nᵏ). The series are accurate to the round-off for |f|≤1/150.
+func (s Spheroid) AuxLat(aux AuxLatitude, φ float64) float64 {
+	if !(-90 <= φ && φ <= 90) {
+		panic("geomys.Spheroid.AuxLat: domain error: `φ`")
+	}
+	C := auxCf(aux, s.Fpp(), false)
+	return auxSeries(φ, C)
+}
+
+// GeoLat -- converts the auxiliary latitude `ζ` (degrees) of kind `aux` to the geographic latitude (degrees).
+// This function causes a runtime panic when ζ∉[-90,90].
+func (s Spheroid) GeoLat(aux AuxLatitude, ζ float64) float64 {
+	if !(-90 <= ζ && ζ <= 90) {
+		panic("geomys.Spheroid.GeoLat: domain error: `ζ`")
+	}
+	C := auxCf(aux, s.Fpp(), true)
+	return auxSeries(ζ, C)
+}
+
+// IsometricLat -- converts the geographic latitude `φ` (degrees) to the isometric latitude ψ (degrees):
+//
+//	ψ = asinh(tan(χ)),
+//
+// where χ is the conformal latitude. The isometric latitude is infinite at the poles.
+// This function causes a runtime panic when φ∉[-90,90].
+func (s Spheroid) IsometricLat(φ float64) float64 {
+	if !(-90 <= φ && φ <= 90) {
+		panic("geomys.Spheroid.IsometricLat: domain error: `φ`")
+	}
+	if math.Abs(φ) == 90 {
+		return math.Inf(int(φ))
+	}
+	sχ, cχ := mym.SinCosD(s.AuxLat(ConformalLat, φ))
+	return math.Asinh(sχ/cχ) * (180 / math.Pi)
+}
+
+// GeoLatIsometric -- converts the isometric latitude `ψ` (degrees) to the geographic latitude (degrees).
+func (s Spheroid) GeoLatIsometric(ψ float64) float64 {
+	χ := math.Atan(math.Sinh(ψ*(math.Pi/180))) * (180 / math.Pi)
+	return s.GeoLat(ConformalLat, χ)
+}
+
+// auxSeries -- evaluates η + ∑ C[k]⋅sin(2kη), η (degrees).
+func auxSeries(η float64, C [7]float64) float64 {
+	if math.Abs(η) == 90 {
+		return η
+	}
+	sη, cη := mym.SinCosD(η)
+	return η + ellSinSeries(sη, cη, C[:])*(180/math.Pi)
+}
+
+// auxCf -- returns the coefficients of the Fourier series for the conversion
+// from the geographic latitude to `aux`, or from `aux` to the geographic latitude
+// when `inv` is true.
+func auxCf(aux AuxLatitude, n float64, inv bool) (C [7]float64) {
+	var coeff []float64
+	switch aux {
+	case GeographicLat:
+		return
+	case ParametricLat, GeocentricLat:
+		// tan(ζ) = m⋅tan(φ) gives C[k] = qᵏ/k, q = (m-1)/(m+1)
+		q := -n
+		if aux == GeocentricLat {
+			q = -2 * n / (1 + n*n)
+		}
+		if inv {
+			q = -q
+		}
+		d := q
+		for k := 1; k <= 6; k++ {
+			C[k] = d / float64(k)
+			d *= q
+		}
+		return
+	case RectifyingLat:
+		if !inv {
+			return merCf(n)
+		}
+		coeff = auxMuPhi[:]
+	case ConformalLat:
+		coeff = auxPhiChi[:]
+		if inv {
+			coeff = auxChiPhi[:]
+		}
+	case AuthalicLat:
+		coeff = auxPhiXi[:]
+		if inv {
+			coeff = auxXiPhi[:]
+		}
+	default:
+		panic("geomys.Spheroid: domain error: `aux`")
+	}
+	d := n
+	oo := 0
+	for k := 1; k <= 6; k++ {
+		m := 6 - k
+		C[k] = d * polyval(m, coeff, oo, n) / coeff[oo+m+1]
+		oo += m + 2
+		d *= n
+	}
+	return
+}
+
+// The coefficients of C[k]/nᵏ as polynomials in n (the highest degree first)
+// followed by the common denominator, k=1,...,6.
+var (
+	auxPhiChi = [...]float64{
+		4642, 3360, -8610, 6300, 3150, -9450, 4725,
+		-1522, 2712, -1365, -1008, 1575, 945,
+		-12686, 4536, 4590, -4914, 2835,
+		-49664, -68040, 55665, 28350,
+		109598, -72666, 31185,
+		444337, 155925,
+	}
+	auxChiPhi = [...]float64{
+		-2854, 390, 1740, -1350, -450, 1350, 675,
+		2323, 8112, -4767, -1512, 2205, 945,
+		73814, -34074, -11016, 10584, 2835,
+		-799144, -268920, 192555, 28350,
+		-724190, 413226, 31185,
+		601676, 22275,
+	}
+	auxPhiXi = [...]float64{
+		-670980, 1894984, 4846842, 11891880, -3783780, -56756700, 42567525,
+		-12467764, -16922360, -37267230, 16216200, 160810650, 212837625,
+		100320856, 225093960, -121351230, -1035134100, 1915538625,
+		-17652372, 11145680, 90195105, 212837625,
+		-9237712, -74388860, 212837625,
+		570284222, 1915538625,
+	}
+	auxXiPhi = [...]float64{
+		28112932, 27361880, -38768730, -97297200, 18918900, 283783500, 212837625,
+		251310128, -258181560, -539008470, 102702600, 652702050, 638512875,
+		-43988240, -77303772, 14679522, 58764420, 54729675,
+		-1472637812, 280316400, 818782965, 638512875,
+		455935736, 1048691280, 638512875,
+		4210684958, 1915538625,
+	}
+	auxMuPhi = [...]float64{
+		0, 269, 0, -432, 0, 768, 512,
+		6759, 0, -7040, 0, 5376, 4096,
+		0, -1251, 0, 604, 384,
+		-15543, 0, 5485, 2560,
+		0, 8011, 2560,
+		293393, 61440,
+	}
+)
@@ -0,0 +1,95 @@
+package geomys
+
+import (
+	"math"
+	"testing"
+)
+
+// meridianQuad -- computes the meridian distance (meters) from the equator to the latitude `φ` (degrees)
+// of `s` by the composite Simpson's rule.
+func meridianQuad(s Spheroid, φ float64) float64 {
+	const n = 4000
+	a, e2 := s.A(), s.E2()
+	M := func(φ float64) float64 {
+		sφ := math.Sin(φ)
+		w := 1 - e2*sφ*sφ
+		return a * (1 - e2) / (w * math.Sqrt(w))
+	}
+	h := φ * (math.Pi / 180) / n
+	// the compensated summation keeps the error of the sum at nanometers
+	sum, c := M(0)+M(n*h), 0.0
+	for i := 1; i < n; i++ {
+		y := float64(2+2*(i%2))*M(float64(i)*h) - c
+		t := sum + y
+		c = (t - sum) - y
+		sum = t
+	}
+	return sum * h / 3
+}
+
+// auxLatClosed -- computes the auxiliary latitude (degrees) of kind `aux` by the closed formulas.
+func auxLatClosed(s Spheroid, aux AuxLatitude, φ float64) float64 {
+	f, e2 := s.F(), s.E2()
+	e := math.Sqrt(e2)
+	sφ, cφ := math.Sincos(φ * (math.Pi / 180))
+	var ζ float64
+	switch aux {
+	case GeographicLat:
+		return φ
+	case ParametricLat:
+		ζ = math.Atan2((1-f)*sφ, cφ)
+	case GeocentricLat:
+		ζ = math.Atan2((1-f)*(1-f)*sφ, cφ)
+	case RectifyingLat:
+		return 90 * meridianQuad(s, φ) / meridianQuad(s, 90)
+	case ConformalLat:
+		ψ := math.Asinh(sφ/cφ) - e*math.Atanh(e*sφ)
+		ζ = math.Atan(math.Sinh(ψ))
+	case AuthalicLat:
+		q := func(sφ float64) float64 {
+			return (1 - e2) * (sφ/(1-e2*sφ*sφ) + math.Atanh(e*sφ)/e)
+		}
+		ζ = math.Asin(math.Max(-1, math.Min(1, q(sφ)/q(1))))
+	}
+	return ζ * (180 / math.Pi)
+}
+
+func TestAuxLat(t *testing.T) {
+	for _, sph := range []Spheroid{WGS1984(), SRMmax()} {
+		for _, aux := range []AuxLatitude{GeographicLat, ParametricLat, GeocentricLat, RectifyingLat, ConformalLat, AuthalicLat} {
+			for φ := -90.0; φ <= 90; φ += 2.5 {
+				ζ := sph.AuxLat(aux, φ)
+				// the closed formula of the authalic latitude loses accuracy near the poles
+				if want := auxLatClosed(sph, aux, φ); math.Abs(ζ-want) > 5e-12 {
+					t.Errorf("%v AuxLat(%v,%v): got %v, want %v", sph, aux, φ, ζ, want)
+				}
+				if φ2 := sph.GeoLat(aux, ζ); math.Abs(φ2-φ) > 1e-12 {
+					t.Errorf("%v GeoLat(%v,%v): got %v, want %v", sph, aux, ζ, φ2, φ)
+				}
+			}
+		}
+	}
+}
+
+func TestIsometricLat(t *testing.T) {
+	for _, sph := range []Spheroid{WGS1984(), SRMmax()} {
+		e := math.Sqrt(sph.E2())
+		for φ := -89.5; φ <= 89.5; φ += 0.5 {
+			ψ := sph.IsometricLat(φ)
+			sφ, cφ := math.Sincos(φ * (math.Pi / 180))
+			want := (math.Asinh(sφ/cφ) - e*math.Atanh(e*sφ)) * (180 / math.Pi)
+			if math.Abs(ψ-want) > 1e-11*math.Max(1, math.Abs(want)) {
+				t.Errorf("%v IsometricLat(%v): got %v, want %v", sph, φ, ψ, want)
+			}
+			if φ2 := sph.GeoLatIsometric(ψ); math.Abs(φ2-φ) > 1e-12 {
+				t.Errorf("%v GeoLatIsometric(%v): got %v, want %v", sph, ψ, φ2, φ)
+			}
+		}
+		for _, φ := range []float64{-90, 90} {
+			ψ := sph.IsometricLat(φ)
+			if !math.IsInf(ψ, int(φ)) || sph.GeoLatIsometric(ψ) != φ {
+				t.Errorf("%v IsometricLat(%v): got %v", sph, φ, ψ)
+			}
+		}
+	}
+}
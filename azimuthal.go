@@ -0,0 +1,310 @@
+package geomys
+
+import (
+	"fmt"
+	"github.com/reconditematter/mym"
+	"math"
+)
+
+// AzimuthalEquidistant -- azimuthal equidistant map projection on the spheroid.
+// The distance and the azimuth of a point from the center are
+// the geodesic distance and the geodesic azimuth.
+//
+// Reference: Karney, C.F.F. Algorithms for geodesics. J Geodesy 87, 43–55 (2013).
+//
+// DOI: https://doi.org/10.1007/s00190-012-0578-z
+type AzimuthalEquidistant struct {
+	par map[string]float64
+	g   Geodesic
+	c   Point
+}
+
+// NewAzimuthalEquidistant -- returns a new azimuthal equidistant map projection
+// based on the spheroid `sph` and centered at the point `c`.
+//
+// The projection has the following parameters:
+//
+//	lat0 -- latitude of the center
+//	lon0 -- longitude of the center
+//	x0   -- false easting
+//	y0   -- false northing
+func NewAzimuthalEquidistant(sph Spheroid, c Point, x0, y0 float64) AzimuthalEquidistant {
+	lat0, lon0, _ := c.Geo()
+	par := map[string]float64{"lat0": lat0, "lon0": lon0, "x0": x0, "y0": y0}
+	return AzimuthalEquidistant{par: par, g: NewGeodesic(sph), c: c}
+}
+
+// Spheroid -- returns the spheroid of the map projection.
+func (prj AzimuthalEquidistant) Spheroid() Spheroid {
+	if prj.par == nil {
+		panic("geomys.AzimuthalEquidistant.Spheroid: uninitialized structure")
+	}
+	//
+	return prj.g.Spheroid()
+}
+
+// Params -- returns the parameters of the map projection.
+func (prj AzimuthalEquidistant) Params() map[string]float64 {
+	if prj.par == nil {
+		panic("geomys.AzimuthalEquidistant.Params: uninitialized structure")
+	}
+	//
+	par := make(map[string]float64)
+	for k, v := range prj.par {
+		par[k] = v
+	}
+	return par
+}
+
+// Project -- transforms a geographic point from
+// the spheroid into a location on the plane.
+func (prj AzimuthalEquidistant) Project(p Point) (xy [2]float64) {
+	if prj.par == nil {
+		panic("geomys.AzimuthalEquidistant.Project: uninitialized structure")
+	}
+	//
+	s, α, _ := prj.g.Inverse(prj.c, p)
+	sα, cα := mym.SinCosD(α)
+	xy[0] = prj.par["x0"] + s*sα
+	xy[1] = prj.par["y0"] + s*cα
+	return
+}
+
+// Unproject -- transforms a location on the plane into
+// a geographic point on the spheroid. Returns an error wrapping
+// ErrOutOfDomain when `xy` is not finite.
+func (prj AzimuthalEquidistant) Unproject(xy [2]float64) (Point, error) {
+	if prj.par == nil {
+		panic("geomys.AzimuthalEquidistant.Unproject: uninitialized structure")
+	}
+	//
+	x, y := xy[0]-prj.par["x0"], xy[1]-prj.par["y0"]
+	if !(mym.FiniteIs(x) && mym.FiniteIs(y)) {
+		return Point{}, fmt.Errorf("geomys.AzimuthalEquidistant.Unproject: %w: `xy`", ErrOutOfDomain)
+	}
+	α := atan2d(x, y)
+	p, _ := prj.g.Direct(prj.c, α, math.Hypot(x, y))
+	return p, nil
+}
+
+// Gnomonic -- gnomonic map projection on the spheroid. The geodesics through
+// the center are mapped to straight lines, the other geodesics are mapped
+// to nearly straight lines. The projection is defined for the points
+// whose geodesic scale M12 relative to the center is positive,
+// which includes the points within about 90° from the center.
+//
+// Reference: Karney, C.F.F. Algorithms for geodesics. J Geodesy 87, 43–55 (2013).
+//
+// DOI: https://doi.org/10.1007/s00190-012-0578-z
+type Gnomonic struct {
+	par map[string]float64
+	g   Geodesic
+	c   Point
+}
+
+const gnomMaxit = 10
+
+var gnomTol = 0.01 * mym.SqrtEps
+
+// NewGnomonic -- returns a new gnomonic map projection
+// based on the spheroid `sph` and centered at the point `c`.
+//
+// The projection has the following parameters:
+//
+//	lat0 -- latitude of the center
+//	lon0 -- longitude of the center
+//	x0   -- false easting
+//	y0   -- false northing
+func NewGnomonic(sph Spheroid, c Point, x0, y0 float64) Gnomonic {
+	lat0, lon0, _ := c.Geo()
+	par := map[string]float64{"lat0": lat0, "lon0": lon0, "x0": x0, "y0": y0}
+	return Gnomonic{par: par, g: NewGeodesic(sph), c: c}
+}
+
+// Spheroid -- returns the spheroid of the map projection.
+func (prj Gnomonic) Spheroid() Spheroid {
+	if prj.par == nil {
+		panic("geomys.Gnomonic.Spheroid: uninitialized structure")
+	}
+	//
+	return prj.g.Spheroid()
+}
+
+// Params -- returns the parameters of the map projection.
+func (prj Gnomonic) Params() map[string]float64 {
+	if prj.par == nil {
+		panic("geomys.Gnomonic.Params: uninitialized structure")
+	}
+	//
+	par := make(map[string]float64)
+	for k, v := range prj.par {
+		par[k] = v
+	}
+	return par
+}
+
+// Project -- transforms a geographic point from
+// the spheroid into a location on the plane.
+// Returns (NaN,NaN) when `p` is outside the domain of the projection.
+func (prj Gnomonic) Project(p Point) (xy [2]float64) {
+	if prj.par == nil {
+		panic("geomys.Gnomonic.Project: uninitialized structure")
+	}
+	//
+	res := prj.g.InverseExt(prj.c, p, OutReducedLength|OutGeodesicScale)
+	if !(res.M12 > 0) {
+		return [2]float64{math.NaN(), math.NaN()}
+	}
+	ρ := res.RedLen / res.M12
+	sα, cα := mym.SinCosD(res.Azi1)
+	xy[0] = prj.par["x0"] + ρ*sα
+	xy[1] = prj.par["y0"] + ρ*cα
+	return
+}
+
+// Unproject -- transforms a location on the plane into
+// a geographic point on the spheroid. Returns an error wrapping
+// ErrOutOfDomain when `xy` is not finite or the iterations do not converge.
+func (prj Gnomonic) Unproject(xy [2]float64) (Point, error) {
+	if prj.par == nil {
+		panic("geomys.Gnomonic.Unproject: uninitialized structure")
+	}
+	//
+	x, y := xy[0]-prj.par["x0"], xy[1]-prj.par["y0"]
+	if !(mym.FiniteIs(x) && mym.FiniteIs(y)) {
+		return Point{}, fmt.Errorf("geomys.Gnomonic.Unproject: %w: `xy`", ErrOutOfDomain)
+	}
+	a := prj.g.Spheroid().A()
+	α := atan2d(x, y)
+	ρ := math.Hypot(x, y)
+	s := a * math.Atan(ρ/a)
+	// when ρ≤a solve ρ(s)=ρ with dρ/ds=1/M12²,
+	// otherwise solve 1/ρ(s)=1/ρ with d(1/ρ)/ds=-1/m12²
+	little := ρ <= a
+	if !little {
+		ρ = 1 / ρ
+	}
+	l := NewGeodesicLine(prj.g, prj.c, α)
+	for i := 0; i < gnomMaxit; i++ {
+		pos := l.genposition(false, s)
+		m, M, _ := l.scales(pos)
+		var ds float64
+		if little {
+			ds = (m - ρ*M) * M
+		} else {
+			ds = (ρ*m - M) * m
+		}
+		s -= ds
+		if !(math.Abs(ds) >= gnomTol*a) {
+			pos = l.genposition(false, s)
+			return pos.p2, nil
+		}
+	}
+	return Point{}, fmt.Errorf("geomys.Gnomonic.Unproject: %w: `xy`", ErrOutOfDomain)
+}
+
+// LambertAzimuthalEqualArea -- Lambert azimuthal equal-area map projection on the spheroid.
+//
+// Reference: IOGP Publication 373-7-2, Geomatics Guidance Note number 7, part 2.
+// Coordinate Conversions and Transformations including Formulas (2019).
+type LambertAzimuthalEqualArea struct {
+	sph      Spheroid
+	par      map[string]float64
+	rq, dq   float64
+	sβ0, cβ0 float64
+}
+
+// NewLambertAzimuthalEqualArea -- returns a new Lambert azimuthal equal-area map projection
+// based on the spheroid `sph` and centered at the point `c`.
+//
+// The projection has the following parameters:
+//
+//	lat0 -- latitude of the center
+//	lon0 -- longitude of the center
+//	x0   -- false easting
+//	y0   -- false northing
+func NewLambertAzimuthalEqualArea(sph Spheroid, c Point, x0, y0 float64) LambertAzimuthalEqualArea {
+	lat0, lon0, _ := c.Geo()
+	par := map[string]float64{"lat0": lat0, "lon0": lon0, "x0": x0, "y0": y0}
+	prj := LambertAzimuthalEqualArea{sph: sph, par: par}
+	// the radius of the authalic sphere
+	prj.rq = sph.A() * math.Sqrt(authq(sph.E2(), 1)/2)
+	prj.sβ0, prj.cβ0 = mym.SinCosD(sph.AuxLat(AuthalicLat, lat0))
+	prj.dq = 1
+	if math.Abs(lat0) < 90 {
+		_, cosφ0 := mym.SinCosD(lat0)
+		prj.dq = sph.PrimeVerticalRadius(lat0) * cosφ0 / (prj.rq * prj.cβ0)
+	}
+	return prj
+}
+
+// Spheroid -- returns the spheroid of the map projection.
+func (prj LambertAzimuthalEqualArea) Spheroid() Spheroid {
+	if prj.par == nil {
+		panic("geomys.LambertAzimuthalEqualArea.Spheroid: uninitialized structure")
+	}
+	//
+	return prj.sph
+}
+
+// Params -- returns the parameters of the map projection.
+func (prj LambertAzimuthalEqualArea) Params() map[string]float64 {
+	if prj.par == nil {
+		panic("geomys.LambertAzimuthalEqualArea.Params: uninitialized structure")
+	}
+	//
+	par := make(map[string]float64)
+	for k, v := range prj.par {
+		par[k] = v
+	}
+	return par
+}
+
+// Project -- transforms a geographic point from
+// the spheroid into a location on the plane.
+// The antipode of the center is mapped to (NaN,NaN).
+func (prj LambertAzimuthalEqualArea) Project(p Point) (xy [2]float64) {
+	if prj.par == nil {
+		panic("geomys.LambertAzimuthalEqualArea.Project: uninitialized structure")
+	}
+	//
+	lat, lon, _ := p.Geo()
+	sβ, cβ := mym.SinCosD(prj.sph.AuxLat(AuthalicLat, lat))
+	sλ, cλ := mym.SinCosD(angNormalize(lon - prj.par["lon0"]))
+	B := prj.rq * math.Sqrt(2/(1+prj.sβ0*sβ+prj.cβ0*cβ*cλ))
+	xy[0] = prj.par["x0"] + B*prj.dq*cβ*sλ
+	xy[1] = prj.par["y0"] + B/prj.dq*(prj.cβ0*sβ-prj.sβ0*cβ*cλ)
+	return
+}
+
+// Unproject -- transforms a location on the plane into
+// a geographic point on the spheroid. Returns an error wrapping
+// ErrOutOfDomain when `xy` is outside the image of the spheroid.
+func (prj LambertAzimuthalEqualArea) Unproject(xy [2]float64) (Point, error) {
+	if prj.par == nil {
+		panic("geomys.LambertAzimuthalEqualArea.Unproject: uninitialized structure")
+	}
+	//
+	x := (xy[0] - prj.par["x0"]) / prj.dq
+	y := (xy[1] - prj.par["y0"]) * prj.dq
+	ρ := math.Hypot(x, y)
+	sc2 := ρ / (2 * prj.rq)
+	if !(sc2 <= 1) {
+		// allow for the round-off at the antipode of the center
+		if !(sc2 <= 1+4*mym.Epsilon) {
+			return Point{}, fmt.Errorf("geomys.LambertAzimuthalEqualArea.Unproject: %w: `xy`", ErrOutOfDomain)
+		}
+		sc2 = 1
+	}
+	// c is the angular distance from the center on the authalic sphere
+	c := 2 * math.Asin(sc2)
+	sc, cc := math.Sincos(c)
+	sβ := cc * prj.sβ0
+	if ρ > 0 {
+		sβ += y * sc * prj.cβ0 / ρ
+	}
+	β := math.Asin(math.Max(-1, math.Min(1, sβ))) * (180 / math.Pi)
+	λ := atan2d(x*sc, ρ*prj.cβ0*cc-y*prj.sβ0*sc)
+	lat := prj.sph.GeoLat(AuthalicLat, β)
+	return Geo(lat, angNormalize(prj.par["lon0"]+λ), 0.0), nil
+}
@@ -0,0 +1,71 @@
+package geomys
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLambertAzimuthalEqualAreaKnown(t *testing.T) {
+	// IOGP Guidance Note 7-2, ETRS89 / LAEA Europe (EPSG:3035)
+	prj := NewLambertAzimuthalEqualArea(GRS1980(), Geo(52, 10, 0), 4321000, 3210000)
+	xy := prj.Project(Geo(50, 5, 0))
+	if math.Abs(xy[0]-3962799.45) > 0.01 || math.Abs(xy[1]-2999718.85) > 0.01 {
+		t.Errorf("Project: got %v", xy)
+	}
+}
+
+func TestAzimuthalRoundTrip(t *testing.T) {
+	sph := WGS1984()
+	g := NewGeodesic(sph)
+	for _, c := range []Point{Geo(0, 0, 0), Geo(52, 10, 0), Geo(-33.9, 151.2, 0), Geo(90, 0, 0)} {
+		prjs := []struct {
+			prj    InvertibleProjection
+			maxarc float64
+		}{
+			{NewAzimuthalEquidistant(sph, c, 0, 0), 170},
+			{NewGnomonic(sph, c, 5e5, 1e5), 75},
+			{NewLambertAzimuthalEqualArea(sph, c, 0, 0), 170},
+		}
+		for _, pr := range prjs {
+			prj := pr.prj
+			for α := -180.0; α < 180; α += 15 {
+				for arc := 0.0; arc <= pr.maxarc; arc += 5 {
+					p, _ := g.Direct(c, α, arc*sph.Rm()*math.Pi/180)
+					xy := prj.Project(p)
+					q, err := prj.Unproject(xy)
+					if err != nil {
+						t.Fatalf("%T %v (%v,%v): %v", prj, c, α, arc, err)
+					}
+					if d, _, _ := g.Inverse(p, q); d > 1e-6 {
+						t.Errorf("%T %v (%v,%v): d=%v", prj, c, α, arc, d)
+					}
+					if _, ok := prj.(AzimuthalEquidistant); ok {
+						s, _, _ := g.Inverse(c, p)
+						if math.Abs(math.Hypot(xy[0], xy[1])-s) > 1e-6 {
+							t.Errorf("%v (%v,%v): ρ=%v, s=%v", c, α, arc, math.Hypot(xy[0], xy[1]), s)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestGnomonicGeodesic(t *testing.T) {
+	// the geodesics are mapped to nearly straight lines
+	sph := WGS1984()
+	g := NewGeodesic(sph)
+	prj := NewGnomonic(sph, Geo(45, 10, 0), 0, 0)
+	p1, p2 := Geo(40, 0, 0), Geo(50, 25, 0)
+	a, b := prj.Project(p1), prj.Project(p2)
+	for _, q := range g.Waypoints(p1, p2, 11) {
+		c := prj.Project(q)
+		d := ((b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])) / math.Hypot(b[0]-a[0], b[1]-a[1])
+		if math.Abs(d) > 100 {
+			t.Errorf("%v: d=%v", q, d)
+		}
+	}
+	if xy := prj.Project(Geo(-45, -170, 0)); !math.IsNaN(xy[0]) {
+		t.Errorf("Project(antipode): got %v", xy)
+	}
+}
@@ -0,0 +1,83 @@
+package geomys
+
+import (
+	"github.com/reconditematter/mym"
+	"math"
+)
+
+// PrimeVerticalRadius -- returns the radius of curvature (meters) of `s` in the prime vertical
+// at the geographic latitude `lat` (degrees):
+//
+//	N = a/√(1-e²⋅sin²φ).
+//
+// This function causes a runtime panic when lat∉[-90,90].
+func (s Spheroid) PrimeVerticalRadius(lat float64) float64 {
+	if !(-90 <= lat && lat <= 90) {
+		panic("geomys.Spheroid.PrimeVerticalRadius: domain error: `lat`")
+	}
+	sinφ, _ := mym.SinCosD(lat)
+	return s.A() / math.Sqrt(1-s.E2()*sinφ*sinφ)
+}
+
+// MeridionalRadius -- returns the radius of curvature (meters) of `s` in the meridian
+// at the geographic latitude `lat` (degrees):
+//
+//	M = a⋅(1-e²)/(1-e²⋅sin²φ)^(3/2).
+//
+// This function causes a runtime panic when lat∉[-90,90].
+func (s Spheroid) MeridionalRadius(lat float64) float64 {
+	if !(-90 <= lat && lat <= 90) {
+		panic("geomys.Spheroid.MeridionalRadius: domain error: `lat`")
+	}
+	sinφ, _ := mym.SinCosD(lat)
+	e2 := s.E2()
+	w2 := 1 - e2*sinφ*sinφ
+	return s.A() * (1 - e2) / (w2 * math.Sqrt(w2))
+}
+
+// GaussianRadius -- returns the Gaussian mean radius of curvature (meters) of `s`
+// at the geographic latitude `lat` (degrees):
+//
+//	R = √(M⋅N) = a⋅√(1-e²)/(1-e²⋅sin²φ).
+//
+// This function causes a runtime panic when lat∉[-90,90].
+func (s Spheroid) GaussianRadius(lat float64) float64 {
+	if !(-90 <= lat && lat <= 90) {
+		panic("geomys.Spheroid.GaussianRadius: domain error: `lat`")
+	}
+	sinφ, _ := mym.SinCosD(lat)
+	e2 := s.E2()
+	return s.A() * math.Sqrt(1-e2) / (1 - e2*sinφ*sinφ)
+}
+
+// EulerRadius -- returns the radius of curvature (meters) of `s` of the normal section
+// in the direction of the azimuth `α` (degrees) at the geographic latitude `lat` (degrees):
+//
+//	R(α) = M⋅N/(N⋅cos²α + M⋅sin²α).
+//
+// This function causes a runtime panic when lat∉[-90,90].
+func (s Spheroid) EulerRadius(lat, α float64) float64 {
+	if !(-90 <= lat && lat <= 90) {
+		panic("geomys.Spheroid.EulerRadius: domain error: `lat`")
+	}
+	_, cosφ := mym.SinCosD(lat)
+	_, cosα := mym.SinCosD(α)
+	// M⋅N/(N⋅cos²α + M⋅sin²α) = N/(1 + e'²⋅cos²φ⋅cos²α)
+	N := s.PrimeVerticalRadius(lat)
+	return N / (1 + s.Ep2()*mym.Sq(cosφ*cosα))
+}
+
+// LatDegreeLength -- returns the length (meters) of one degree of latitude of `s`
+// at the geographic latitude `lat` (degrees), that is M⋅π/180.
+// This function causes a runtime panic when lat∉[-90,90].
+func (s Spheroid) LatDegreeLength(lat float64) float64 {
+	return s.MeridionalRadius(lat) * (math.Pi / 180)
+}
+
+// LonDegreeLength -- returns the length (meters) of one degree of longitude of `s`
+// at the geographic latitude `lat` (degrees), that is N⋅cos(φ)⋅π/180.
+// This function causes a runtime panic when lat∉[-90,90].
+func (s Spheroid) LonDegreeLength(lat float64) float64 {
+	_, cosφ := mym.SinCosD(lat)
+	return s.PrimeVerticalRadius(lat) * cosφ * (math.Pi / 180)
+}
@@ -0,0 +1,73 @@
+package geomys
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCurvature(t *testing.T) {
+	sph := WGS1984()
+	tests := []struct {
+		lat, N, M, R, dlat, dlon float64
+	}{
+		{0, 6378137, 6335439.3272928195, 6356752.314245179, 110574.27582159436, 111319.49079327358},
+		{45, 6388838.290121148, 6367381.815619548, 6378101.030201018, 111131.77741417562, 78846.83509397812},
+		{-90, 6399593.625758493, 6399593.625758492, 6399593.625758493, 111693.97955912749, 0},
+	}
+	for _, tt := range tests {
+		for _, c := range []struct {
+			name      string
+			got, want float64
+		}{
+			{"PrimeVerticalRadius", sph.PrimeVerticalRadius(tt.lat), tt.N},
+			{"MeridionalRadius", sph.MeridionalRadius(tt.lat), tt.M},
+			{"GaussianRadius", sph.GaussianRadius(tt.lat), tt.R},
+			{"EulerRadius(0)", sph.EulerRadius(tt.lat, 0), tt.M},
+			{"EulerRadius(90)", sph.EulerRadius(tt.lat, 90), tt.N},
+			{"LatDegreeLength", sph.LatDegreeLength(tt.lat), tt.dlat},
+			{"LonDegreeLength", sph.LonDegreeLength(tt.lat), tt.dlon},
+		} {
+			if math.Abs(c.got-c.want) > 1e-8 {
+				t.Errorf("%s(%v): got %v, want %v", c.name, tt.lat, c.got, c.want)
+			}
+		}
+	}
+	// Euler's theorem: 1/R(α) = cos²α/M + sin²α/N
+	for _, s := range []Spheroid{WGS1984(), SRMmax()} {
+		for lat := -80.0; lat <= 80; lat += 20 {
+			M, N := s.MeridionalRadius(lat), s.PrimeVerticalRadius(lat)
+			for α := 0.0; α < 360; α += 30 {
+				sα, cα := math.Sincos(α * (math.Pi / 180))
+				want := 1 / (cα*cα/M + sα*sα/N)
+				if r := s.EulerRadius(lat, α); math.Abs(r-want) > 1e-8 {
+					t.Errorf("EulerRadius(%v,%v): got %v, want %v", lat, α, r, want)
+				}
+			}
+			// M is the derivative of the meridian distance
+			const h = 1e-3
+			dm := (s.MeridianDistance(lat+h) - s.MeridianDistance(lat-h)) / (2 * h * math.Pi / 180)
+			if math.Abs(dm-M) > 1e-3 {
+				t.Errorf("MeridionalRadius(%v): got %v, want %v", lat, M, dm)
+			}
+		}
+	}
+}
+
+func TestCurvaturePanics(t *testing.T) {
+	sph := WGS1984()
+	for name, f := range map[string]func(){
+		"PrimeVerticalRadius": func() { sph.PrimeVerticalRadius(91) },
+		"MeridionalRadius":    func() { sph.MeridionalRadius(-91) },
+		"GaussianRadius":      func() { sph.GaussianRadius(math.NaN()) },
+		"EulerRadius":         func() { sph.EulerRadius(100, 0) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: no panic on a latitude out of range", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
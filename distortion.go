@@ -0,0 +1,180 @@
+package geomys
+
+import (
+	"github.com/reconditematter/mym"
+	"math"
+)
+
+// Distortion -- the local distortion of a map projection at a geographic point.
+type Distortion struct {
+	H     float64 // scale factor along the meridian
+	K     float64 // scale factor along the parallel
+	S     float64 // areal scale factor
+	A, B  float64 // maximal and minimal scale factors, the semi-axes of Tissot's indicatrix
+	Omega float64 // maximal angular deformation (degrees)
+	Theta float64 // angle between the meridian and the parallel on the plane (degrees)
+	Gamma float64 // meridian convergence (degrees), the bearing of grid north clockwise from true north
+}
+
+// tissotStep -- the step (degrees) of the numeric differentiation of a map projection.
+// tissotPole -- the minimal distance (degrees) from a pole of the numeric differentiation.
+const (
+	tissotStep = 1e-4
+	tissotPole = 1e-2
+)
+
+// Tissot -- computes the distortion of the map projection `prj` at the point `p`.
+// When `prj` implements ConformalProjection, the distortion is computed from
+// the analytic point scale and meridian convergence. Otherwise the Jacobian of `prj`
+// is computed by the central differences, and the distortion within tissotPole (10⁻² degrees)
+// from a pole is the distortion on the parallel at the distance tissotPole from the pole.
+// The distortion of a Grid is the distortion of its underlying map projection.
+//
+// Reference: Snyder, J.P. Map Projections: A Working Manual (1987), p.20-26.
+func Tissot(prj MapProjection, p Point) Distortion {
+	if g, ok := prj.(Grid); ok {
+		prj = g.Projection()
+	}
+	if cp, ok := prj.(ConformalProjection); ok {
+		_, γ, k := cp.ProjectExt(p)
+		return Distortion{H: k, K: k, S: k * k, A: k, B: k, Omega: 0, Theta: 90, Gamma: γ}
+	}
+	return tissotNum(prj, p)
+}
+
+// tissotNum -- computes the distortion of `prj` at `p` using the numeric Jacobian.
+func tissotNum(prj MapProjection, p Point) Distortion {
+	sph := prj.Spheroid()
+	lat, lon, _ := p.Geo()
+	lat = math.Max(-90+tissotPole, math.Min(90-tissotPole, lat))
+	_, cosφ := mym.SinCosD(lat)
+	// the steps along the meridian and the parallel have about the same length,
+	// unless the parallel is too short near a pole
+	δ := tissotStep
+	δλ := math.Min(tissotStep/cosφ, tissotPole)
+	eφ := tissotDiff(
+		prj.Project(Geo(lat-δ, lon, 0.0)),
+		prj.Project(Geo(lat, lon, 0.0)),
+		prj.Project(Geo(lat+δ, lon, 0.0)),
+	)
+	eλ := tissotDiff(
+		prj.Project(Geo(lat, angNormalize(lon-δλ), 0.0)),
+		prj.Project(Geo(lat, lon, 0.0)),
+		prj.Project(Geo(lat, angNormalize(lon+δλ), 0.0)),
+	)
+	// the derivatives along the meridian and the parallel per unit length on the spheroid
+	dm := sph.MeridionalRadius(lat) * δ * (math.Pi / 180)
+	dp := sph.PrimeVerticalRadius(lat) * cosφ * δλ * (math.Pi / 180)
+	hx, hy := eφ[0]/dm, eφ[1]/dm
+	kx, ky := eλ[0]/dp, eλ[1]/dp
+	//
+	var d Distortion
+	d.H = math.Hypot(hx, hy)
+	d.K = math.Hypot(kx, ky)
+	s := kx*hy - ky*hx
+	d.S = math.Abs(s)
+	d.Theta = math.Abs(atan2d(s, hx*kx+hy*ky))
+	d.Gamma = -atan2d(hx, hy)
+	// a'=a+b, b'=a-b
+	h2k2 := d.H*d.H + d.K*d.K
+	ap := math.Sqrt(h2k2 + 2*d.S)
+	bp := math.Sqrt(math.Max(0, h2k2-2*d.S))
+	d.A = (ap + bp) / 2
+	d.B = (ap - bp) / 2
+	d.Omega = 2 * math.Asin(math.Min(1, bp/ap)) * (180 / math.Pi)
+	return d
+}
+
+// tissotDiff -- computes the central difference of the locations `xy1` and `xy2`
+// around `xy0`. When the map is torn between the locations, for example at the antimeridian,
+// or one of the locations is not finite, returns the smaller one-sided difference.
+func tissotDiff(xy1, xy0, xy2 [2]float64) (d [2]float64) {
+	d1 := [2]float64{xy0[0] - xy1[0], xy0[1] - xy1[1]}
+	d2 := [2]float64{xy2[0] - xy0[0], xy2[1] - xy0[1]}
+	n1, n2 := math.Hypot(d1[0], d1[1]), math.Hypot(d2[0], d2[1])
+	dd := math.Hypot(d2[0]-d1[0], d2[1]-d1[1])
+	if dd <= math.Max(n1, n2) {
+		return [2]float64{(xy2[0] - xy1[0]) / 2, (xy2[1] - xy1[1]) / 2}
+	}
+	if n1 <= n2 || math.IsNaN(n2) {
+		return d1
+	}
+	return d2
+}
+
+// DistortionStats -- the minimum, the maximum and the area-weighted mean of a distortion measure.
+type DistortionStats struct {
+	Min, Max, Mean float64
+}
+
+// DistortionSummary -- the distortion of a map projection sampled over a region.
+type DistortionSummary struct {
+	H, K, S, A, B, Omega DistortionStats
+	N                    int // the number of the samples with a finite distortion
+}
+
+// SummarizeDistortion -- samples the distortion of the map projection `prj`
+// at the centers of the n-by-n grid of cells, which partition the region bounded
+// by the parallels and the meridians through the south-west corner `sw` and
+// the north-east corner `ne`. The region crosses the antimeridian when the longitude
+// of `sw` is greater than the longitude of `ne`. The means are weighted by the areas
+// of the cells on the spheroid. The samples with a non-finite distortion are skipped.
+// This function causes a runtime panic when n<1
+// or the latitude of `sw` is greater than the latitude of `ne`.
+func SummarizeDistortion(prj MapProjection, sw, ne Point, n int) DistortionSummary {
+	if n < 1 {
+		panic("geomys.SummarizeDistortion: domain error: `n`")
+	}
+	lats, lonw, _ := sw.Geo()
+	latn, lone, _ := ne.Geo()
+	if lats > latn {
+		panic("geomys.SummarizeDistortion: domain error: `sw`")
+	}
+	//
+	sph := prj.Spheroid()
+	dlon := lone - lonw
+	if dlon < 0 {
+		dlon += 360
+	}
+	var sum DistortionSummary
+	stats := func(d Distortion) [6]float64 {
+		return [6]float64{d.H, d.K, d.S, d.A, d.B, d.Omega}
+	}
+	ptrs := [6]*DistortionStats{&sum.H, &sum.K, &sum.S, &sum.A, &sum.B, &sum.Omega}
+	for _, st := range ptrs {
+		st.Min, st.Max = math.Inf(1), math.Inf(-1)
+	}
+	var wsum float64
+	for i := 0; i < n; i++ {
+		lat := lats + (latn-lats)*(float64(i)+0.5)/float64(n)
+		// the area of the cell is proportional to M⋅N⋅cos(φ)
+		_, cosφ := mym.SinCosD(lat)
+		w := sph.MeridionalRadius(lat) * sph.PrimeVerticalRadius(lat) * cosφ
+		for j := 0; j < n; j++ {
+			lon := angNormalize(lonw + dlon*(float64(j)+0.5)/float64(n))
+			v := stats(Tissot(prj, Geo(lat, lon, 0.0)))
+			finite := true
+			for _, x := range v {
+				finite = finite && mym.FiniteIs(x)
+			}
+			if !finite {
+				continue
+			}
+			for k, st := range ptrs {
+				st.Min = math.Min(st.Min, v[k])
+				st.Max = math.Max(st.Max, v[k])
+				st.Mean += w * v[k]
+			}
+			wsum += w
+			sum.N++
+		}
+	}
+	for _, st := range ptrs {
+		if sum.N == 0 {
+			*st = DistortionStats{math.NaN(), math.NaN(), math.NaN()}
+			continue
+		}
+		st.Mean /= wsum
+	}
+	return sum
+}
@@ -0,0 +1,118 @@
+package geomys
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTissotConformal(t *testing.T) {
+	sph := WGS1984()
+	prjs := []ConformalProjection{
+		NewTransverseMercator(sph, 0, 9, 0.9996, 5e5, 0),
+		NewUTM(sph, 0, true),
+		NewUTM(sph, 0, false),
+		NewMercator(sph, 30, 0, 0, 0),
+		NewLambertConformalConic(sph, 33, 45, 23, -96, 0, 0),
+		NewLambertConformalConic(sph, -20, -40, -30, 135, 0, 0),
+		NewPolarStereographicB(sph, -71, 0, 0, 0),
+	}
+	for _, prj := range prjs {
+		for _, lat := range []float64{-88, -60, -35, -1, 0, 20, 47, 75, 89} {
+			for _, lon := range []float64{-170, -100, -2, 7, 11, 44, 140} {
+				p := Geo(lat, lon, 0)
+				dlon, _ := angDiff(prj.Params()["lon0"], lon)
+				if xy := prj.Project(p); !(math.Abs(xy[0]) < 1e7 && math.Abs(xy[1]) < 2e7) || math.Abs(dlon) > 90 {
+					// far outside the useful domain
+					continue
+				}
+				dn := tissotNum(prj, p)
+				_, γ, k := prj.ProjectExt(p)
+				dγ, _ := angDiff(γ, dn.Gamma)
+				if math.Abs(dn.H/k-1) > 1e-7 || math.Abs(dn.K/k-1) > 1e-7 || math.Abs(dγ) > 1e-6 || dn.Omega > 1e-5 {
+					t.Errorf("%T %v (%v,%v): got k=%v γ=%v, numeric %+v", prj, prj.Params(), lat, lon, k, γ, dn)
+				}
+			}
+		}
+	}
+}
+
+func TestTissotPole(t *testing.T) {
+	for _, lat := range []float64{90, -90} {
+		d := Tissot(NewUTM(WGS1984(), 0, lat > 0), Geo(lat, 0, 0))
+		if math.Abs(d.K-0.994) > 1e-15 || d.Omega != 0 {
+			t.Errorf("UPS (%v,0): got %+v", lat, d)
+		}
+	}
+}
+
+func TestTissotEqualArea(t *testing.T) {
+	sph := GRS1980()
+	prjs := []MapProjection{
+		NewAlbers(sph, 29.5, 45.5, 23, -96),
+		NewLambertAzimuthalEqualArea(sph, Geo(52, 10, 0), 4321000, 3210000),
+		NewEqualEarth(sph, 0),
+		NewMollweide(sph, 0),
+	}
+	for _, prj := range prjs {
+		for _, lat := range []float64{-60, -20, 0, 30, 45, 70, 89.9} {
+			for _, lon := range []float64{-180, -120, -96, -30, 0, 10, 60} {
+				d := Tissot(prj, Geo(lat, lon, 0))
+				if math.Abs(d.S-1) > 1e-7 || math.Abs(d.A*d.B-1) > 1e-7 {
+					t.Errorf("%T (%v,%v): got %+v", prj, lat, lon, d)
+				}
+			}
+		}
+	}
+}
+
+func TestTissotAlbers(t *testing.T) {
+	prj := NewAlbers(Clarke1866(), 29.5, 45.5, 23, -96)
+	// the standard parallels are true to scale
+	for _, lat := range []float64{29.5, 45.5} {
+		d := Tissot(prj, Geo(lat, -80, 0))
+		if math.Abs(d.H-1) > 1e-7 || math.Abs(d.K-1) > 1e-7 || d.Omega > 1e-5 || math.Abs(d.Theta-90) > 1e-5 {
+			t.Errorf("(%v,-80): got %+v", lat, d)
+		}
+	}
+	// k = √(C-n⋅q)/m, h = 1/k
+	sph := Clarke1866()
+	e2 := sph.E2()
+	m := func(lat float64) float64 {
+		s, c := math.Sincos(lat * math.Pi / 180)
+		return c / math.Sqrt(1-e2*s*s)
+	}
+	q := func(lat float64) float64 { return authq(e2, math.Sin(lat*math.Pi/180)) }
+	n := (m(29.5)*m(29.5) - m(45.5)*m(45.5)) / (q(45.5) - q(29.5))
+	C := m(29.5)*m(29.5) + n*q(29.5)
+	for _, lat := range []float64{20, 25, 37.5, 52} {
+		k := math.Sqrt(C-n*q(lat)) / m(lat)
+		d := Tissot(prj, Geo(lat, -120, 0))
+		if math.Abs(d.K-k) > 1e-8 || math.Abs(d.H-1/k) > 1e-8 {
+			t.Errorf("(%v,-120): got %+v, want k=%v", lat, d, k)
+		}
+	}
+}
+
+func TestSummarizeDistortion(t *testing.T) {
+	sph := GRS1980()
+	sw, ne := Geo(24, -125, 0), Geo(50, -66, 0)
+	conus := SummarizeDistortion(NewAlbers(sph, 29.5, 45.5, 23, -96), sw, ne, 20)
+	wide := SummarizeDistortion(NewAlbers(sph, 20, 60, 23, -96), sw, ne, 20)
+	if conus.N != 400 || wide.N != 400 {
+		t.Fatalf("N: got %v, %v", conus.N, wide.N)
+	}
+	if math.Abs(conus.S.Min-1) > 1e-7 || math.Abs(conus.S.Max-1) > 1e-7 || math.Abs(conus.S.Mean-1) > 1e-7 {
+		t.Errorf("S: got %+v", conus.S)
+	}
+	if !(conus.K.Min < 1 && 1 < conus.K.Max && conus.K.Min <= conus.K.Mean && conus.K.Mean <= conus.K.Max) {
+		t.Errorf("K: got %+v", conus.K)
+	}
+	if !(conus.Omega.Max < wide.Omega.Max && conus.Omega.Mean < wide.Omega.Mean) {
+		t.Errorf("Omega: got %+v, %+v", conus.Omega, wide.Omega)
+	}
+	// the region crossing the antimeridian
+	pac := SummarizeDistortion(NewMercator(sph, 0, 180, 0, 0), Geo(-10, 170, 0), Geo(10, -170, 0), 4)
+	if pac.N != 16 || !(pac.K.Max < 1/math.Cos(10*math.Pi/180)) || pac.Omega.Max != 0 {
+		t.Errorf("antimeridian: got %+v", pac)
+	}
+}
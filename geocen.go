@@ -25,12 +25,11 @@ func (geocen Geocentric) Spheroid() Spheroid {
 // Forward -- converts the geographic coordinates of `p`
 // to the geocentric coordinates `xyz`.
 func (geocen Geocentric) Forward(p Point) (xyz [3]float64) {
-	a := geocen.s.A()
 	e2 := geocen.s.E2()
 	φ, λ, _ := p.Geo()
 	sinφ, cosφ := mym.SinCosD(φ)
 	sinλ, cosλ := mym.SinCosD(λ)
-	N := a / math.Sqrt(1-e2*sinφ*sinφ)
+	N := geocen.s.PrimeVerticalRadius(φ)
 	xyz[0] = N * cosφ * cosλ
 	xyz[1] = N * cosφ * sinλ
 	xyz[2] = N * (1 - e2) * sinφ
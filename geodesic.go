@@ -0,0 +1,520 @@
+package geomys
+
+import (
+	"github.com/reconditematter/mym"
+	"math"
+)
+
+// Geodesic -- geodesic solver for a spheroidal model of the Earth.
+//
+// The inverse and direct problems are solved to round-off accuracy
+// for all pairs of points, including nearly antipodal ones.
+//
+// Reference: Karney, C.F.F. Algorithms for geodesics.
+// J Geodesy 87, 43–55 (2013).
+//
+// DOI: https://doi.org/10.1007/s00190-012-0578-z
+type Geodesic struct {
+	sph                  Spheroid
+	a, f, f1, b, e2, ep2 float64
+	n, c2, etol2         float64
+	a3x                  [8]float64
+	c3x                  [28]float64
+	c4x                  [36]float64
+}
+
+const (
+	geodTiny    = 0x1p-511 // ≈ square root of the smallest normal number
+	geodTol0    = mym.Epsilon
+	geodTol1    = 200 * geodTol0
+	geodTol2    = 0x1p-26 // square root of geodTol0
+	geodTolb    = geodTol0 * geodTol2
+	geodXthresh = 1000 * geodTol2
+	geodMaxit1  = 20
+	geodMaxit2  = geodMaxit1 + 53 + 10
+)
+
+// NewGeodesic -- returns a geodesic solver for the spheroid `sph`.
+func NewGeodesic(sph Spheroid) Geodesic {
+	f := sph.F()
+	g := Geodesic{sph: sph, a: sph.A(), f: f, f1: 1 - f, b: sph.B(), e2: sph.E2(), ep2: sph.Ep2(), n: sph.Fpp()}
+	g.c2 = mym.Sq(sph.Rs())
+	g.etol2 = 0.1 * geodTol2 / math.Sqrt(math.Max(0.001, f)*math.Min(1, 1-f/2)/2)
+	g.a3x = ellA3x(g.n)
+	g.c3x = ellC3x(g.n)
+	g.c4x = ellC4x(g.n)
+	return g
+}
+
+// Spheroid -- returns the spheroid of `g`.
+func (g Geodesic) Spheroid() Spheroid {
+	return g.sph
+}
+
+// Inverse -- solves the inverse problem: given two points `p1` and `p2`, find
+// the geodesic distance `s12` (meters) between the points, also find the azimuths
+// `α1` (degrees) at `p1` and `α2` (degrees) at `p2`.
+func (g Geodesic) Inverse(p1, p2 Point) (s12 float64, α1, α2 float64) {
+	lat1, lon1, _ := p1.Geo()
+	lat2, lon2, _ := p2.Geo()
+	inv := g.geninverse(lat1, lon1, lat2, lon2, 0)
+	s12 = inv.s12
+	α1 = atan2d(inv.sα1, inv.cα1)
+	α2 = atan2d(inv.sα2, inv.cα2)
+	return
+}
+
+// InverseExt -- solves the inverse problem for the points `p1` and `p2` as Inverse does,
+// also computes the outputs requested by `mask` (OutReducedLength,OutGeodesicScale,OutArea).
+func (g Geodesic) InverseExt(p1, p2 Point, mask int) InverseResult {
+	lat1, lon1, _ := p1.Geo()
+	lat2, lon2, _ := p2.Geo()
+	inv := g.geninverse(lat1, lon1, lat2, lon2, mask)
+	res := InverseResult{Mask: mask, Dist: inv.s12, Azi1: atan2d(inv.sα1, inv.cα1), Azi2: atan2d(inv.sα2, inv.cα2)}
+	if mask&OutReducedLength != 0 {
+		res.RedLen = inv.m12
+	}
+	if mask&OutGeodesicScale != 0 {
+		res.M12, res.M21 = inv.M12, inv.M21
+	}
+	if mask&OutArea != 0 {
+		res.Area = inv.S12
+	}
+	return res
+}
+
+// Direct -- solves the direct problem: given the source point `p1`, the azimuth `α1` (degrees),
+// and the geodesic distance `s12` (meters), find the target point `p2`, also find the azimuth
+// `α2` (degrees) at `p2`.
+func (g Geodesic) Direct(p1 Point, α1 float64, s12 float64) (p2 Point, α2 float64) {
+	return NewGeodesicLine(g, p1, α1).Position(s12)
+}
+
+// geodinv -- the intermediate results of the inverse problem.
+type geodinv struct {
+	s12, m12, σ12      float64
+	M12, M21, S12      float64
+	sα1, cα1, sα2, cα2 float64
+}
+
+func (g Geodesic) geninverse(lat1, lon1, lat2, lon2 float64, mask int) (inv geodinv) {
+	lon12, lon12s := angDiff(lon1, lon2)
+	lonsign := 1.0
+	if math.Signbit(lon12) {
+		lonsign = -1
+	}
+	// if very close to being on the same half-meridian, then make it so
+	lon12 = lonsign * angRound(lon12)
+	lon12s = angRound((180 - lon12) - lonsign*lon12s)
+	λ12 := lon12 * (math.Pi / 180)
+	var sλ12, cλ12 float64
+	if lon12 > 90 {
+		sλ12, cλ12 = mym.SinCosD(lon12s)
+		cλ12 = -cλ12
+	} else {
+		sλ12, cλ12 = mym.SinCosD(lon12)
+	}
+	//
+	lat1 = angRound(lat1)
+	lat2 = angRound(lat2)
+	// swap the points so that p1 has the larger absolute latitude
+	swapp := 1.0
+	if math.Abs(lat1) < math.Abs(lat2) {
+		swapp = -1
+		lonsign = -lonsign
+		lat1, lat2 = lat2, lat1
+	}
+	// make lat1 <= -0
+	latsign := -1.0
+	if math.Signbit(lat1) {
+		latsign = 1
+	}
+	lat1 *= latsign
+	lat2 *= latsign
+	// now 0 <= lon12 <= 180, -90 <= lat1 <= -0, and lat1 <= lat2 <= -lat1
+	//
+	sβ1, cβ1 := mym.SinCosD(lat1)
+	sβ1 *= g.f1
+	sβ1, cβ1 = norm2(sβ1, cβ1)
+	cβ1 = math.Max(geodTiny, cβ1)
+	//
+	sβ2, cβ2 := mym.SinCosD(lat2)
+	sβ2 *= g.f1
+	sβ2, cβ2 = norm2(sβ2, cβ2)
+	cβ2 = math.Max(geodTiny, cβ2)
+	//
+	if cβ1 < -sβ1 {
+		if cβ2 == cβ1 {
+			sβ2 = math.Copysign(sβ1, sβ2)
+		}
+	} else {
+		if math.Abs(sβ2) == -sβ1 {
+			cβ2 = cβ1
+		}
+	}
+	//
+	dn1 := math.Sqrt(1 + g.ep2*sβ1*sβ1)
+	dn2 := math.Sqrt(1 + g.ep2*sβ2*sβ2)
+	//
+	var sα1, cα1, sα2, cα2, σ12, s12x, m12x, M12, M21 float64
+	meridian := lat1 == -90 || sλ12 == 0
+	if meridian {
+		// the end points are on a single full meridian
+		sα1, cα1 = sλ12, cλ12
+		sα2, cα2 = 0, 1
+		sσ1, cσ1 := sβ1, cα1*cβ1
+		sσ2, cσ2 := sβ2, cα2*cβ2
+		σ12 = math.Atan2(math.Max(0, cσ1*sσ2-sσ1*cσ2)+0, cσ1*cσ2+sσ1*sσ2)
+		ln := g.lengths(g.n, σ12, sσ1, cσ1, dn1, sσ2, cσ2, dn2, cβ1, cβ2)
+		s12x, m12x, M12, M21 = ln.s12b, ln.m12b, ln.M12, ln.M21
+		if σ12 < 1 || m12x >= 0 {
+			if σ12 < 3*geodTiny || (σ12 < geodTol0 && (s12x < 0 || m12x < 0)) {
+				σ12, m12x, s12x = 0, 0, 0
+			}
+			m12x *= g.b
+			s12x *= g.b
+		} else {
+			// m12<0: the meridian is not the shortest path
+			meridian = false
+		}
+	}
+	// sω12=2 marks that sin(ω12) and cos(ω12) are yet to be computed
+	ω12, sω12, cω12 := 0.0, 2.0, 0.0
+	if !meridian && sβ1 == 0 && (g.f <= 0 || lon12s >= g.f*180) {
+		// the geodesic runs along the equator
+		sα1, cα1, sα2, cα2 = 1, 0, 1, 0
+		s12x = g.a * λ12
+		σ12 = λ12 / g.f1
+		ω12 = σ12
+		m12x = g.b * math.Sin(σ12)
+		M12 = math.Cos(σ12)
+		M21 = M12
+	} else if !meridian {
+		var dnm float64
+		σ12, sα1, cα1, sα2, cα2, dnm = g.inverseStart(sβ1, cβ1, dn1, sβ2, cβ2, dn2, λ12, sλ12, cλ12)
+		if σ12 >= 0 {
+			// short lines
+			s12x = σ12 * g.b * dnm
+			m12x = dnm * dnm * g.b * math.Sin(σ12/dnm)
+			M12 = math.Cos(σ12 / dnm)
+			M21 = M12
+			ω12 = λ12 / (g.f1 * dnm)
+		} else {
+			// Newton's method on f(α1) = λ12(α1) - λ12, keeping
+			// a bracket (α1a,α1b) of the root for the bisection fallback
+			var lam geodlam
+			sα1a, cα1a, sα1b, cα1b := geodTiny, 1.0, geodTiny, -1.0
+			tripn, tripb := false, false
+			for numit := 0; ; numit++ {
+				lam = g.lambda12(sβ1, cβ1, dn1, sβ2, cβ2, dn2, sα1, cα1, sλ12, cλ12, numit < geodMaxit1)
+				v, dv := lam.v, lam.dv
+				tol := 1.0
+				if tripn {
+					tol = 8
+				}
+				if tripb || !(math.Abs(v) >= tol*geodTol0) || numit == geodMaxit2 {
+					break
+				}
+				if v > 0 && (numit > geodMaxit1 || cα1/sα1 > cα1b/sα1b) {
+					sα1b, cα1b = sα1, cα1
+				} else if v < 0 && (numit > geodMaxit1 || cα1/sα1 < cα1a/sα1a) {
+					sα1a, cα1a = sα1, cα1
+				}
+				if numit < geodMaxit1 && dv > 0 {
+					dα1 := -v / dv
+					if math.Abs(dα1) < math.Pi {
+						sdα1, cdα1 := math.Sincos(dα1)
+						nsα1 := sα1*cdα1 + cα1*sdα1
+						if nsα1 > 0 {
+							cα1 = cα1*cdα1 - sα1*sdα1
+							sα1 = nsα1
+							sα1, cα1 = norm2(sα1, cα1)
+							tripn = math.Abs(v) <= 16*geodTol0
+							continue
+						}
+					}
+				}
+				// fall back to the bisection of the bracket
+				sα1 = (sα1a + sα1b) / 2
+				cα1 = (cα1a + cα1b) / 2
+				sα1, cα1 = norm2(sα1, cα1)
+				tripn = false
+				tripb = math.Abs(sα1a-sα1)+(cα1a-cα1) < geodTolb || math.Abs(sα1-sα1b)+(cα1-cα1b) < geodTolb
+			}
+			sα2, cα2, σ12 = lam.sα2, lam.cα2, lam.σ12
+			ln := g.lengths(lam.eps, σ12, lam.sσ1, lam.cσ1, dn1, lam.sσ2, lam.cσ2, dn2, cβ1, cβ2)
+			s12x, m12x, M12, M21 = ln.s12b*g.b, ln.m12b*g.b, ln.M12, ln.M21
+			// ω12 = λ12 - dω12
+			sdω12, cdω12 := math.Sincos(lam.dω12)
+			sω12 = sλ12*cdω12 - cλ12*sdω12
+			cω12 = cλ12*cdω12 + sλ12*sdω12
+		}
+	}
+	//
+	if mask&OutArea != 0 {
+		if !meridian && sω12 == 2 {
+			sω12, cω12 = math.Sincos(ω12)
+		}
+		inv.S12 = g.area(meridian, sβ1, cβ1, sβ2, cβ2, sα1, cα1, sα2, cα2, sω12, cω12) * swapp * lonsign * latsign
+		inv.S12 += 0
+	}
+	//
+	// undo the canonical transformation
+	if swapp < 0 {
+		sα1, sα2 = sα2, sα1
+		cα1, cα2 = cα2, cα1
+		M12, M21 = M21, M12
+	}
+	sα1 *= swapp * lonsign
+	cα1 *= swapp * latsign
+	sα2 *= swapp * lonsign
+	cα2 *= swapp * latsign
+	//
+	inv.s12 = 0 + s12x
+	inv.m12 = 0 + m12x
+	inv.σ12 = σ12
+	inv.M12, inv.M21 = M12, M21
+	inv.sα1, inv.cα1, inv.sα2, inv.cα2 = sα1, cα1, sα2, cα2
+	return
+}
+
+// geodlen -- the scaled lengths computed by `lengths`.
+type geodlen struct {
+	s12b, m12b, m0, M12, M21 float64
+}
+
+// lengths -- computes the distance s12b=s12/b, the reduced length m12b=m12/b,
+// the coefficient m0 of the secular term of m12b, and the geodesic scales M12, M21.
+func (g Geodesic) lengths(eps, σ12, sσ1, cσ1, dn1, sσ2, cσ2, dn2, cβ1, cβ2 float64) (ln geodlen) {
+	A1 := ellA1m1f(eps)
+	C1a := ellC1f(eps)
+	A2 := ellA2m1f(eps)
+	C2a := ellC2f(eps)
+	m0 := A1 - A2
+	A1++
+	A2++
+	//
+	B1 := ellSinSeries(sσ2, cσ2, C1a[:]) - ellSinSeries(sσ1, cσ1, C1a[:])
+	B2 := ellSinSeries(sσ2, cσ2, C2a[:]) - ellSinSeries(sσ1, cσ1, C2a[:])
+	J12 := m0*σ12 + (A1*B1 - A2*B2)
+	//
+	ln.s12b = A1 * (σ12 + B1)
+	ln.m0 = m0
+	ln.m12b = dn2*(cσ1*sσ2) - dn1*(sσ1*cσ2) - cσ1*cσ2*J12
+	cσ12 := cσ1*cσ2 + sσ1*sσ2
+	t := g.ep2 * (cβ1 - cβ2) * (cβ1 + cβ2) / (dn1 + dn2)
+	ln.M12 = cσ12 + (t*sσ2-cσ2*J12)*sσ1/dn1
+	ln.M21 = cσ12 - (t*sσ1-cσ1*J12)*sσ2/dn2
+	return
+}
+
+// geodlam -- the intermediate results computed by `lambda12`.
+type geodlam struct {
+	v, dv, dω12, eps   float64
+	sα2, cα2, σ12      float64
+	sσ1, cσ1, sσ2, cσ2 float64
+}
+
+// lambda12 -- computes the longitude difference λ12 on the spheroid for the
+// starting azimuth α1 and returns v=λ12(α1)-λ12 together with its derivative
+// `dv` (when `diffp` is true).
+func (g Geodesic) lambda12(sβ1, cβ1, dn1, sβ2, cβ2, dn2, sα1, cα1, sλ120, cλ120 float64, diffp bool) (lam geodlam) {
+	if sβ1 == 0 && cα1 == 0 {
+		// break the degeneracy of the equatorial line
+		cα1 = -geodTiny
+	}
+	sα0 := sα1 * cβ1
+	cα0 := math.Hypot(cα1, sα1*sβ1)
+	//
+	sσ1, sω1 := sβ1, sα0*sβ1
+	cσ1 := cα1 * cβ1
+	cω1 := cσ1
+	sσ1, cσ1 = norm2(sσ1, cσ1)
+	//
+	sα2 := sα1
+	if cβ2 != cβ1 {
+		sα2 = sα0 / cβ2
+	}
+	var cα2 float64
+	if cβ2 != cβ1 || math.Abs(sβ2) != -sβ1 {
+		var d float64
+		if cβ1 < -sβ1 {
+			d = (cβ2 - cβ1) * (cβ1 + cβ2)
+		} else {
+			d = (sβ1 - sβ2) * (sβ1 + sβ2)
+		}
+		cα2 = math.Sqrt(mym.Sq(cα1*cβ1)+d) / cβ2
+	} else {
+		cα2 = math.Abs(cα1)
+	}
+	sσ2, sω2 := sβ2, sα0*sβ2
+	cσ2 := cα2 * cβ2
+	cω2 := cσ2
+	sσ2, cσ2 = norm2(sσ2, cσ2)
+	//
+	σ12 := math.Atan2(math.Max(0, cσ1*sσ2-sσ1*cσ2)+0, cσ1*cσ2+sσ1*sσ2)
+	sω12 := math.Max(0, cω1*sω2-sω1*cω2) + 0
+	cω12 := cω1*cω2 + sω1*sω2
+	η := math.Atan2(sω12*cλ120-cω12*sλ120, cω12*cλ120+sω12*sλ120)
+	//
+	k2 := cα0 * cα0 * g.ep2
+	eps := k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+	C3a := ellC3f(&g.c3x, eps)
+	B312 := ellSinSeries(sσ2, cσ2, C3a[:]) - ellSinSeries(sσ1, cσ1, C3a[:])
+	dω12 := -g.f * ellA3f(&g.a3x, eps) * sα0 * (σ12 + B312)
+	lam.v = η + dω12
+	//
+	if diffp {
+		if cα2 == 0 {
+			lam.dv = -2 * g.f1 * dn1 / sβ1
+		} else {
+			ln := g.lengths(eps, σ12, sσ1, cσ1, dn1, sσ2, cσ2, dn2, cβ1, cβ2)
+			lam.dv = ln.m12b * g.f1 / (cα2 * cβ2)
+		}
+	}
+	lam.dω12, lam.eps = dω12, eps
+	lam.sα2, lam.cα2, lam.σ12 = sα2, cα2, σ12
+	lam.sσ1, lam.cσ1, lam.sσ2, lam.cσ2 = sσ1, cσ1, sσ2, cσ2
+	return
+}
+
+// area -- computes the area between the geodesic and the equator
+// in the canonical configuration of the inverse problem.
+func (g Geodesic) area(meridian bool, sβ1, cβ1, sβ2, cβ2, sα1, cα1, sα2, cα2, sω12, cω12 float64) (S12 float64) {
+	sα0 := sα1 * cβ1
+	cα0 := math.Hypot(cα1, sα1*sβ1)
+	if cα0 != 0 && sα0 != 0 {
+		sσ1, cσ1 := norm2(sβ1, cα1*cβ1)
+		sσ2, cσ2 := norm2(sβ2, cα2*cβ2)
+		k2 := cα0 * cα0 * g.ep2
+		eps := k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+		A4 := g.a * g.a * cα0 * sα0 * g.e2
+		C4a := ellC4f(&g.c4x, eps)
+		S12 = A4 * (ellCosSeries(sσ2, cσ2, C4a[:]) - ellCosSeries(sσ1, cσ1, C4a[:]))
+	}
+	//
+	var α12 float64
+	if !meridian && cω12 > -0.7071 && sβ2-sβ1 < 1.75 {
+		// tan(Γ/2) = tan(ω12/2)⋅(tan(β1/2)+tan(β2/2))/(1+tan(β1/2)⋅tan(β2/2))
+		dω12, dβ1, dβ2 := 1+cω12, 1+cβ1, 1+cβ2
+		α12 = 2 * math.Atan2(sω12*(sβ1*dβ2+sβ2*dβ1), dω12*(sβ1*sβ2+dβ1*dβ2))
+	} else {
+		sα12 := sα2*cα1 - cα2*sα1
+		cα12 := cα2*cα1 + sα2*sα1
+		if sα12 == 0 && cα12 < 0 {
+			sα12 = geodTiny * cα1
+			cα12 = -1
+		}
+		α12 = math.Atan2(sα12, cα12)
+	}
+	return S12 + g.c2*α12
+}
+
+// inverseStart -- returns a starting point for Newton's method (σ12<0);
+// for short lines, returns the solution (σ12>=0) and the azimuth α2.
+func (g Geodesic) inverseStart(sβ1, cβ1, dn1, sβ2, cβ2, dn2, λ12, sλ12, cλ12 float64) (σ12, sα1, cα1, sα2, cα2, dnm float64) {
+	σ12 = -1
+	sβ12 := sβ2*cβ1 - cβ2*sβ1
+	cβ12 := cβ2*cβ1 + sβ2*sβ1
+	sβ12a := sβ2*cβ1 + cβ2*sβ1
+	shortline := cβ12 >= 0 && sβ12 < 0.5 && cβ2*λ12 < 0.5
+	var sω12, cω12 float64
+	if shortline {
+		sβm2 := mym.Sq(sβ1 + sβ2)
+		sβm2 /= sβm2 + mym.Sq(cβ1+cβ2)
+		dnm = math.Sqrt(1 + g.ep2*sβm2)
+		sω12, cω12 = math.Sincos(λ12 / (g.f1 * dnm))
+	} else {
+		sω12, cω12 = sλ12, cλ12
+	}
+	//
+	sα1 = cβ2 * sω12
+	if cω12 >= 0 {
+		cα1 = sβ12 + cβ2*sβ1*sω12*sω12/(1+cω12)
+	} else {
+		cα1 = sβ12a - cβ2*sβ1*sω12*sω12/(1-cω12)
+	}
+	sσ12 := math.Hypot(sα1, cα1)
+	cσ12 := sβ1*sβ2 + cβ1*cβ2*cω12
+	//
+	if shortline && sσ12 < g.etol2 {
+		// really short lines
+		sα2 = cβ1 * sω12
+		if cω12 >= 0 {
+			cα2 = sβ12 - cβ1*sβ2*sω12*sω12/(1+cω12)
+		} else {
+			cα2 = sβ12 - cβ1*sβ2*(1-cω12)
+		}
+		sα2, cα2 = norm2(sα2, cα2)
+		σ12 = math.Atan2(sσ12, cσ12)
+	} else if math.Abs(g.n) > 0.1 || cσ12 >= 0 || sσ12 >= 6*math.Abs(g.n)*math.Pi*cβ1*cβ1 {
+		// the zeroth order spherical approximation is good enough
+	} else {
+		// nearly antipodal points: scale λ12 and β2 so that the antipode
+		// is at the origin and the singular point is at (x,y)=(-1,0)
+		λ12x := math.Atan2(-sλ12, -cλ12)
+		k2 := sβ1 * sβ1 * g.ep2
+		eps := k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+		λscale := g.f * cβ1 * ellA3f(&g.a3x, eps) * math.Pi
+		βscale := λscale * cβ1
+		x := λ12x / λscale
+		y := sβ12a / βscale
+		if y > -geodTol1 && x > -1-geodXthresh {
+			sα1 = math.Min(1, -x)
+			cα1 = -math.Sqrt(1 - sα1*sα1)
+		} else {
+			k := astroid(x, y)
+			ω12a := λscale * (-x * k / (1 + k))
+			sω12, cω12 = math.Sincos(ω12a)
+			cω12 = -cω12
+			sα1 = cβ2 * sω12
+			cα1 = sβ12a - cβ2*sβ1*sω12*sω12/(1-cω12)
+		}
+	}
+	if !(sα1 <= 0) {
+		sα1, cα1 = norm2(sα1, cα1)
+	} else {
+		sα1, cα1 = 1, 0
+	}
+	return
+}
+
+// astroid -- solves k⁴+2k³-(x²+y²-1)k²-2y²k-y² = 0 for the positive root k.
+func astroid(x, y float64) (k float64) {
+	p := x * x
+	q := y * y
+	r := (p + q - 1) / 6
+	if q == 0 && r <= 0 {
+		return 0
+	}
+	S := p * q / 4
+	r2 := r * r
+	r3 := r * r2
+	disc := S * (S + 2*r3)
+	u := r
+	if disc >= 0 {
+		T3 := S + r3
+		if T3 < 0 {
+			T3 -= math.Sqrt(disc)
+		} else {
+			T3 += math.Sqrt(disc)
+		}
+		T := math.Cbrt(T3)
+		u += T
+		if T != 0 {
+			u += r2 / T
+		}
+	} else {
+		ang := math.Atan2(math.Sqrt(-disc), -(S + r3))
+		u += 2 * r * math.Cos(ang/3)
+	}
+	v := math.Sqrt(u*u + q)
+	var uv float64
+	if u < 0 {
+		uv = q / (v - u)
+	} else {
+		uv = u + v
+	}
+	w := (uv - q) / (2 * v)
+	return uv / (math.Sqrt(uv+w*w) + w)
+}
@@ -0,0 +1,166 @@
+package geomys
+
+import (
+	"math"
+	"testing"
+)
+
+// geodTestData -- a sample of GeodTest.dat (WGS1984), the columns are
+// lat1, lon1, azi1, lat2, lon2, azi2, s12, a12, m12, M12, M21, S12.
+//
+// Reference: Karney, C.F.F. Test set for geodesics (2010).
+//
+// DOI: https://doi.org/10.5281/zenodo.32156
+var geodTestData = [][12]float64{
+	{35.60777, -139.44815, 111.098748429560326, -11.17491, -69.95921, 129.289270889708762, 8935244.5604818305, 80.50729714281974, 6273170.2055303837, 0.16606318447386067, 0.16479116945612937, 12841384694976.432},
+	{55.52454, 106.05087, 22.020059880982801, 77.03196, 197.18234, 109.112041110671519, 4105086.1713924406, 36.892740690445894, 3828869.3344387607, 0.80076349608092607, 0.80101006984201008, 61674961290615.615},
+	{-21.97856, 142.59065, -32.44456876433189, 41.84138, 98.56635, -41.84359951440466, 8394328.894657671, 75.62930491011522, 6161154.5773110616, 0.24816339233950381, 0.24930251203627892, -6637997720646.717},
+	{-66.99028, 112.2363, 173.73491240878403, -12.70631, 285.90344, 2.512956620913668, 11150344.2312080241, 100.278634181155759, 6289939.5670446687, -0.17199490274700385, -0.17722569526345708, -121287239862139.744},
+	{-17.42761, 173.34268, -159.033557661192928, -15.84784, 5.93557, -20.787484651536988, 16076603.1631180673, 144.640108810286253, 3732902.1583877189, -0.81273638700070476, -0.81299800519154474, 97825992354058.708},
+	{32.84994, 48.28919, 150.492927788121982, -56.28556, 202.29132, 48.113449399816759, 16727068.9438164461, 150.565799985466607, 3147838.1910180939, -0.87334918086923126, -0.86505036767110637, -72445258525585.010},
+	{6.96833, 52.74123, 92.581585386317712, -7.39675, 206.17291, 90.721692165923907, 17102477.2496958388, 154.147366239113561, 2772035.6169917581, -0.89991282520302447, -0.89986892177110739, -1311796973197.995},
+	{-50.56724, -16.30485, -105.439679907590164, -33.56571, -94.97412, -47.348547835650331, 6455670.5118668696, 58.083719495371259, 5409150.7979815838, 0.53053508035997263, 0.52988722644436602, 41071447902810.047},
+	{-58.93002, -8.90775, 140.965397902500679, -8.91104, 133.13503, 19.255429433416599, 11756066.0219864627, 105.755691241406877, 6151101.2270708536, -0.26548622269867183, -0.27068483874510741, -86143460552774.735},
+	{-68.82867, -74.28391, 93.774347763114881, -50.63005, -8.36685, 34.65564085411343, 3956936.926063544, 35.572254987389284, 3708890.9544062657, 0.81443963736383502, 0.81420859815358342, -41845309450093.787},
+	{-10.62672, -32.0898, -86.426713286747751, 5.883, -134.31681, -80.473780971034875, 11470869.3864563009, 103.387395634504061, 6184411.6622659713, -0.23138683500430237, -0.23155097622286792, 4198803992123.548},
+	{-21.76221, 166.90563, 29.319421206936428, 48.72884, 213.97627, 43.508671946410168, 9098627.3986554915, 81.963476716121964, 6299240.9166992283, 0.13965943368590333, 0.14152969707656796, 10024709850277.476},
+	{-19.79938, -174.47484, 71.167275780171533, -11.99349, -154.35109, 65.589099775199228, 2319004.8601169389, 20.896611684802389, 2267960.8703918325, 0.93427001867125849, 0.93424887135032789, -3935477535005.785},
+	{-11.95887, -116.94513, 92.712619830452549, 4.57352, 7.16501, 78.64960934409585, 13834722.5801401374, 124.688684161089762, 5228093.177931598, -0.56879356755666463, -0.56918731952397221, -9919582785894.853},
+	{-87.85331, 85.66836, -65.120313040242748, 66.48646, 16.09921, -4.888658719272296, 17286615.3147144645, 155.58592449699137, 2635887.4729110181, -0.90697975771398578, -0.91095608883042767, 42667211366919.534},
+	{1.74708, 128.32011, -101.584843631173858, -11.16617, 11.87109, -86.325793296437476, 12942901.1241347408, 116.650512484301857, 5682744.8413270572, -0.44857868222697644, -0.44824490340007729, 10763055294345.653},
+	{-25.72959, -144.90758, -153.647468693117198, -57.70581, -269.17879, -48.343983158876487, 9413446.7452453107, 84.664533838404295, 6356176.6898881281, 0.09492245755254703, 0.09737058264766572, 74515122850712.444},
+	{-41.22777, 122.32875, 14.285113402275739, -7.57291, 130.37946, 10.805303085187369, 3812686.035106021, 34.34330804743883, 3588703.8812128856, 0.82605222593217889, 0.82572158200920196, -2456961531057.857},
+	{11.01307, 138.25278, 79.43682622782374, 6.62726, 247.05981, 103.708090215522657, 11911190.819018408, 107.341669954114577, 6070904.722786735, -0.29767608923657404, -0.29785143390252321, 17121631423099.696},
+	{-29.47124, 95.14681, -163.779130441688382, -27.46601, -69.15955, -15.909335945554969, 13487015.8381145492, 121.294026715742277, 5481428.9945736388, -0.51527225545373252, -0.51556587964721788, 104679964020340.318},
+}
+
+// geodTestPoints -- returns the points of a row of geodTestData.
+func geodTestPoints(c [12]float64) (p1, p2 Point) {
+	return Geo(c[0], angNormalize(c[1]), 0), Geo(c[3], angNormalize(c[4]), 0)
+}
+
+func TestGeodesicGeodTest(t *testing.T) {
+	g := NewGeodesic(WGS1984())
+	for _, c := range geodTestData {
+		p1, p2 := geodTestPoints(c)
+		s12, α1, α2 := g.Inverse(p1, p2)
+		if math.Abs(s12-c[6]) > 1e-8 || math.Abs(α1-c[2]) > 1e-12 || math.Abs(α2-c[5]) > 1e-12 {
+			t.Errorf("Inverse %v: got %v %v %v", c[:6], s12, α1, α2)
+		}
+		q, α2 := g.Direct(p1, c[2], c[6])
+		lat, lon, _ := q.Geo()
+		dlon, _ := angDiff(c[4], lon)
+		if math.Abs(lat-c[3]) > 1e-12 || math.Abs(dlon) > 1e-12 || math.Abs(α2-c[5]) > 1e-12 {
+			t.Errorf("Direct %v: got %v %v", c[:6], q, α2)
+		}
+	}
+}
+
+func TestGeodesicSpecial(t *testing.T) {
+	g := NewGeodesic(WGS1984())
+	cases := []struct {
+		name                   string
+		lat1, lon1, lat2, lon2 float64
+		α1, α2, s12, tol       float64 // NaN azimuths are not checked
+	}{
+		{"JFK-CDG", 40.6, -73.8, 49.01666667, 2.55, 53.47022, 111.59367, 5853226, 0.5},
+		{"short", 36.493349428792, 0, 36.49334942879201, .0000008, math.NaN(), math.NaN(), 0.072, 0.5e-3},
+		{"antipodal", 88.202499451857, 0, -88.202499451857, 179.981022032992859592, math.NaN(), math.NaN(), 20003898.214, 0.5e-3},
+		{"antipodal", 89.262080389218, 0, -89.262080389218, 179.992207982775375662, math.NaN(), math.NaN(), 20003925.854, 0.5e-3},
+		{"antipodal", 89.333123580033, 0, -89.333123580032997687, 179.99295812360148422, math.NaN(), math.NaN(), 20003926.881, 0.5e-3},
+		{"antipodal", 56.320923501171, 0, -56.320923501171, 179.664747671772880215, math.NaN(), math.NaN(), 19993558.287, 0.5e-3},
+		{"antipodal", 52.784459512564, 0, -52.784459512563990912, 179.634407464943777557, math.NaN(), math.NaN(), 19991596.095, 0.5e-3},
+		{"antipodal", 48.522876735459, 0, -48.52287673545898293, 179.599720456223079643, math.NaN(), math.NaN(), 19989144.774, 0.5e-3},
+		{"antipodal", 27.2, 0, -27.1, 179.5, 45.82468716758, 134.22776532670, 19974354.765767, 1e-6},
+		{"Wellington-Salamanca", -(41 + 19/60.0), 174 + 49/60.0, 40 + 58/60.0, -(5 + 30/60.0), 160.39137649664, 19.50042925176, 19960543.857179, 1e-6},
+		{"equatorial", 0, 0, 0, 179, 90, 90, 19926189, 0.5},
+		{"equatorial", 0, 0, 0, 179.5, 55.96650, 124.03350, 19980862, 0.5},
+		{"equatorial", 0, 0, 0, 180, 0, 180, 20003931, 0.5},
+		{"equatorial", 0, 0, 1, 180, 0, 180, 19893357, 0.5},
+		{"meridional", 5, 0.00000000000001, 10, 180, 0, 180, 18345191.174332713, 5e-9},
+		{"meridional", 10, 20, -10, 20, 180, 180, 2211709.666468744, 1e-8},
+		{"pole", 0, 0, 90, 0, 0, 0, 10001965.729312724, 1e-8},
+		{"pole", 90, 0, -90, 0, 180, 180, 20003931.458625447, 1e-8},
+		{"pole", 90, 0, 90, 180, 0, 180, 0, 0},
+		{"coincident", 20.001, 0, 20.001, 0, 180, 180, 0, 0},
+	}
+	for _, c := range cases {
+		s12, α1, α2 := g.Inverse(Geo(c.lat1, c.lon1, 0), Geo(c.lat2, c.lon2, 0))
+		if math.Abs(s12-c.s12) > c.tol {
+			t.Errorf("%s (%v,%v,%v,%v): got s12=%v, want %v", c.name, c.lat1, c.lon1, c.lat2, c.lon2, s12, c.s12)
+		}
+		// the tolerance of the azimuths is 0.5e-5 degrees for the rounded values
+		if !math.IsNaN(c.α1) && (math.Abs(α1-c.α1) > 0.5e-5 || math.Abs(α2-c.α2) > 0.5e-5) {
+			t.Errorf("%s (%v,%v,%v,%v): got α1=%v α2=%v", c.name, c.lat1, c.lon1, c.lat2, c.lon2, α1, α2)
+		}
+	}
+}
+
+func TestGeodesicDirect(t *testing.T) {
+	g := NewGeodesic(WGS1984())
+	cases := []struct {
+		lat1, lon1, α1, s12 float64
+		lat2, lon2, α2, tol float64
+	}{
+		{40.63972222, -73.77888889, 53.5, 5850e3, 49.01467, 2.56106, 111.62947, 0.5e-5},
+		// to the pole
+		{0.01777745589997, 30, 0, 10e6, 90, -150, 180, 0.5e-5},
+		// from the pole, backwards
+		{90, 10, 180, -1e6, 81.04623, -170, 0, 0.5e-5},
+		// beyond the antipode
+		{40, -75, -10, 2e7, -39.97041, 105.08711, -170.00436, 0.5e-5},
+	}
+	for _, c := range cases {
+		q, α2 := g.Direct(Geo(c.lat1, c.lon1, 0), c.α1, c.s12)
+		lat, lon, _ := q.Geo()
+		if math.Abs(lat-c.lat2) > c.tol || math.Abs(lon-c.lon2) > c.tol || math.Abs(α2-c.α2) > c.tol {
+			t.Errorf("Direct (%v,%v,%v,%v): got %v %v", c.lat1, c.lon1, c.α1, c.s12, q, α2)
+		}
+	}
+}
+
+func TestGeodesicSRMmax(t *testing.T) {
+	// the solutions are verified by the numerical integration
+	// of the geodesic equations on the spheroid
+	g := NewGeodesic(SRMmax())
+	cases := [][7]float64{
+		{35, -40, -12, 70, 88.300671834639, 122.999118904114, 12619945.5994318},
+		{30, 0, -30, 179.2, 50.2852012942746, 129.714798705725, 20009402.6826058},
+		{40, 0, -39.5, 179.6, 14.6219175173902, 165.485303638995, 19979306.0005149},
+		{0, 0, 0, 170, 90, 90, 18989182.2616983},
+		{0, 0, 0, 179.5, 24.6606149517905, 155.339385048209, 20027575.0803025},
+		{-30, 10, 50, 10, 0, 0, 8847132.34593033},
+		{89.5, 0, -89.6, 179.9, 179.600305465979, 0.499618071805401, 20027982.9744786},
+	}
+	for _, c := range cases {
+		p1, p2 := Geo(c[0], c[1], 0), Geo(c[2], c[3], 0)
+		s12, α1, α2 := g.Inverse(p1, p2)
+		if math.Abs(s12-c[6]) > 1e-6 || math.Abs(α1-c[4]) > 1e-11 || math.Abs(α2-c[5]) > 1e-11 {
+			t.Errorf("Inverse %v: got %v %v %v", c[:4], s12, α1, α2)
+		}
+		q, _ := g.Direct(p1, α1, s12)
+		if d, _, _ := g.Inverse(p2, q); d > 1e-8 {
+			t.Errorf("Direct %v: got %v, d=%v", c[:4], q, d)
+		}
+	}
+}
+
+func TestGeoMatrixGeodesic(t *testing.T) {
+	sph := WGS1984()
+	g := NewGeodesic(sph)
+	p := []Point{Geo(0, 0, 0), Geo(90, 0, 0), Geo(-33.9, 151.2, 0), Geo(51.5, -0.1, 0), Geo(-51.5, 179.9, 0)}
+	M := GeoMatrix(sph, p, DistGeodesic)
+	for i := range p {
+		for j := range p {
+			want := 0.0
+			if i < j {
+				want, _, _ = g.Inverse(p[i], p[j])
+			} else if i > j {
+				want, _, _ = g.Inverse(p[j], p[i])
+			}
+			if M.Get(i, j) != want {
+				t.Errorf("GeoMatrix(%v,%v): got %v, want %v", i, j, M.Get(i, j), want)
+			}
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package geomys
+
+import (
+	"github.com/reconditematter/mym"
+	"math"
+)
+
+// GeodesicLine -- a geodesic starting at a given point in a given direction.
+// The setup is done once, so that computing many points along
+// the geodesic is cheap.
+type GeodesicLine struct {
+	g                   Geodesic
+	lon1, sα0, cα0      float64
+	sσ1, cσ1, sω1, cω1  float64
+	A1m1, B11, sτ1, cτ1 float64
+	A3c, B31            float64
+	sα1, cα1, A4, B41   float64
+	eps, cβ1, dn1       float64
+	C1a, C1pa           [9]float64
+	C3a, C4a            [8]float64
+}
+
+// NewGeodesicLine -- returns the geodesic of the solver `g` that starts
+// at the point `p1` with the azimuth `α1` (degrees).
+func NewGeodesicLine(g Geodesic, p1 Point, α1 float64) GeodesicLine {
+	lat1, lon1, _ := p1.Geo()
+	l := GeodesicLine{g: g, lon1: lon1}
+	sα1, cα1 := mym.SinCosD(angRound(angNormalize(α1)))
+	l.sα1, l.cα1 = sα1, cα1
+	//
+	sβ1, cβ1 := mym.SinCosD(angRound(lat1))
+	sβ1 *= g.f1
+	sβ1, cβ1 = norm2(sβ1, cβ1)
+	cβ1 = math.Max(geodTiny, cβ1)
+	l.cβ1, l.dn1 = cβ1, math.Sqrt(1+g.ep2*sβ1*sβ1)
+	//
+	l.sα0 = sα1 * cβ1
+	l.cα0 = math.Hypot(cα1, sα1*sβ1)
+	l.sσ1, l.sω1 = sβ1, l.sα0*sβ1
+	l.cσ1 = 1
+	if sβ1 != 0 || cα1 != 0 {
+		l.cσ1 = cβ1 * cα1
+	}
+	l.cω1 = l.cσ1
+	l.sσ1, l.cσ1 = norm2(l.sσ1, l.cσ1)
+	//
+	k2 := l.cα0 * l.cα0 * g.ep2
+	eps := k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+	l.eps = eps
+	l.A1m1 = ellA1m1f(eps)
+	l.C1a = ellC1f(eps)
+	l.B11 = ellSinSeries(l.sσ1, l.cσ1, l.C1a[:])
+	s, c := math.Sincos(l.B11)
+	l.sτ1 = l.sσ1*c + l.cσ1*s
+	l.cτ1 = l.cσ1*c - l.sσ1*s
+	l.C1pa = ellC1pf(eps)
+	l.C3a = ellC3f(&g.c3x, eps)
+	l.A3c = -g.f * l.sα0 * ellA3f(&g.a3x, eps)
+	l.B31 = ellSinSeries(l.sσ1, l.cσ1, l.C3a[:])
+	l.C4a = ellC4f(&g.c4x, eps)
+	l.A4 = g.a * g.a * l.cα0 * l.sα0 * g.e2
+	l.B41 = ellCosSeries(l.sσ1, l.cσ1, l.C4a[:])
+	return l
+}
+
+// Geodesic -- returns the geodesic solver of `l`.
+func (l GeodesicLine) Geodesic() Geodesic {
+	return l.g
+}
+
+// Position -- returns the point `p2` at the distance `s12` (meters)
+// from the start of `l`, also returns the azimuth `α2` (degrees) at `p2`.
+func (l GeodesicLine) Position(s12 float64) (p2 Point, α2 float64) {
+	pos := l.genposition(false, s12)
+	return pos.p2, pos.α2
+}
+
+// ArcPosition -- returns the point `p2` at the arc length `σ12` (degrees)
+// on the auxiliary sphere from the start of `l`, also returns the azimuth
+// `α2` (degrees) at `p2` and the distance `s12` (meters) to `p2`.
+func (l GeodesicLine) ArcPosition(σ12 float64) (p2 Point, α2, s12 float64) {
+	pos := l.genposition(true, σ12)
+	return pos.p2, pos.α2, pos.s12
+}
+
+// geodpos -- the intermediate results of the direct problem,
+// `lon12` is the unrolled longitude difference (degrees),
+// `S12` is the area between the geodesic and the equator,
+// the rest are the arc length and the reduced latitude at `p2`.
+type geodpos struct {
+	p2                      Point
+	α2, s12, lon12, S12     float64
+	σ12, sσ2, cσ2, sβ2, cβ2 float64
+}
+
+func (l GeodesicLine) genposition(arcmode bool, s12σ12 float64) (pos geodpos) {
+	g := l.g
+	var σ12, sσ12, cσ12, B12 float64
+	if arcmode {
+		σ12 = s12σ12 * (math.Pi / 180)
+		sσ12, cσ12 = mym.SinCosD(s12σ12)
+	} else {
+		τ12 := s12σ12 / (g.b * (1 + l.A1m1))
+		s, c := math.Sincos(τ12)
+		B12 = -ellSinSeries(l.sτ1*c+l.cτ1*s, l.cτ1*c-l.sτ1*s, l.C1pa[:])
+		σ12 = τ12 - (B12 - l.B11)
+		sσ12, cσ12 = math.Sincos(σ12)
+	}
+	//
+	sσ2 := l.sσ1*cσ12 + l.cσ1*sσ12
+	cσ2 := l.cσ1*cσ12 - l.sσ1*sσ12
+	if arcmode {
+		B12 = ellSinSeries(sσ2, cσ2, l.C1a[:])
+		pos.s12 = g.b * (1 + l.A1m1) * (σ12 + (B12 - l.B11))
+	} else {
+		pos.s12 = s12σ12
+	}
+	sβ2 := l.cα0 * sσ2
+	cβ2 := math.Hypot(l.sα0, l.cα0*cσ2)
+	if cβ2 == 0 {
+		// sα0=0 and cσ2=0: break the degeneracy
+		cβ2, cσ2 = geodTiny, geodTiny
+	}
+	//
+	sω2, cω2 := l.sα0*sσ2, cσ2
+	ω12 := math.Atan2(sω2*l.cω1-cω2*l.sω1, cω2*l.cω1+sω2*l.sω1)
+	B32 := ellSinSeries(sσ2, cσ2, l.C3a[:])
+	λ12 := ω12 + l.A3c*(σ12+(B32-l.B31))
+	lon2 := angNormalize(angNormalize(l.lon1) + angNormalize(λ12*(180/math.Pi)))
+	lat2 := atan2d(sβ2, g.f1*cβ2)
+	// ω12 counted with the number of turns around the axis
+	E := math.Copysign(1, l.sα0)
+	ω12 = E * (σ12 - (math.Atan2(sσ2, cσ2) - math.Atan2(l.sσ1, l.cσ1)) +
+		(math.Atan2(E*sω2, cω2) - math.Atan2(E*l.sω1, l.cω1)))
+	pos.lon12 = (ω12 + l.A3c*(σ12+(B32-l.B31))) * (180 / math.Pi)
+	//
+	pos.p2 = Geo(lat2, lon2, 0.0)
+	pos.α2 = atan2d(l.sα0, l.cα0*cσ2)
+	pos.σ12, pos.sσ2, pos.cσ2, pos.sβ2, pos.cβ2 = σ12, sσ2, cσ2, sβ2, cβ2
+	//
+	var sα12, cα12 float64
+	if l.cα0 == 0 || l.sα0 == 0 {
+		sα2, cα2 := l.sα0, l.cα0*cσ2
+		sα12 = sα2*l.cα1 - cα2*l.sα1
+		cα12 = cα2*l.cα1 + sα2*l.sα1
+	} else {
+		// α12 = α2 - α1 without the cancellation near σ12=0
+		if cσ12 <= 0 {
+			sα12 = l.cσ1*(1-cσ12) + sσ12*l.sσ1
+		} else {
+			sα12 = sσ12 * (l.cσ1*sσ12/(1+cσ12) + l.sσ1)
+		}
+		sα12 *= l.cα0 * l.sα0
+		cα12 = l.sα0*l.sα0 + l.cα0*l.cα0*l.cσ1*cσ2
+	}
+	pos.S12 = g.c2*math.Atan2(sα12, cα12) + l.A4*(ellCosSeries(sσ2, cσ2, l.C4a[:])-l.B41)
+	return
+}
+
+// scales -- computes the reduced length `m12` (meters) and
+// the geodesic scales `M12`, `M21` at the position `pos` on `l`.
+func (l GeodesicLine) scales(pos geodpos) (m12, M12, M21 float64) {
+	g := l.g
+	dn2 := math.Sqrt(1 + g.ep2*pos.sβ2*pos.sβ2)
+	ln := g.lengths(l.eps, pos.σ12, l.sσ1, l.cσ1, l.dn1, pos.sσ2, pos.cσ2, dn2, l.cβ1, pos.cβ2)
+	return g.b * ln.m12b, ln.M12, ln.M21
+}
+
+// Waypoints -- returns `n` points evenly spaced in distance along
+// the geodesic between `p1` and `p2`, including both end points.
+// This function causes a runtime panic when n<2.
+func (g Geodesic) Waypoints(p1, p2 Point, n int) []Point {
+	if n < 2 {
+		panic("geomys.Geodesic.Waypoints: domain error: `n`")
+	}
+	s12, α1, _ := g.Inverse(p1, p2)
+	l := NewGeodesicLine(g, p1, α1)
+	pts := make([]Point, n)
+	pts[0], pts[n-1] = p1, p2
+	for i := 1; i < n-1; i++ {
+		pts[i], _ = l.Position(s12 * float64(i) / float64(n-1))
+	}
+	return pts
+}
@@ -0,0 +1,62 @@
+package geomys
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGeodesicLinePosition(t *testing.T) {
+	g := NewGeodesic(WGS1984())
+	for _, c := range geodTestData {
+		p1, p2 := geodTestPoints(c)
+		l := NewGeodesicLine(g, p1, c[2])
+		// the end point of GeodTest.dat by the distance and by the arc length
+		q, α2 := l.Position(c[6])
+		if d, _, _ := g.Inverse(p2, q); d > 1e-8 || math.Abs(α2-c[5]) > 1e-12 {
+			t.Errorf("Position %v: got %v %v", c[:6], q, α2)
+		}
+		q, α2, s12 := l.ArcPosition(c[7])
+		if d, _, _ := g.Inverse(p2, q); d > 1e-8 || math.Abs(α2-c[5]) > 1e-12 || math.Abs(s12-c[6]) > 1e-8 {
+			t.Errorf("ArcPosition %v: got %v %v %v", c[:6], q, α2, s12)
+		}
+		// the intermediate points
+		for _, σ := range []float64{-30, 1e-3, 45, 135, 270} {
+			q, α2, s12 := l.ArcPosition(σ)
+			r, β2 := l.Position(s12)
+			if d, _, _ := g.Inverse(q, r); d > 1e-8 || math.Abs(α2-β2) > 1e-11 {
+				t.Errorf("ArcPosition %v σ=%v: got %v %v, Position %v %v", c[:6], σ, q, α2, r, β2)
+			}
+			r, β2 = g.Direct(p1, c[2], s12)
+			if d, _, _ := g.Inverse(q, r); d > 1e-8 || math.Abs(α2-β2) > 1e-11 {
+				t.Errorf("Direct %v s=%v: got %v %v, want %v %v", c[:6], s12, r, β2, q, α2)
+			}
+		}
+	}
+}
+
+func TestGeodesicWaypoints(t *testing.T) {
+	for _, sph := range []Spheroid{WGS1984(), SRMmax()} {
+		g := NewGeodesic(sph)
+		for _, pp := range [][2]Point{
+			{Geo(40.6, -73.8, 0), Geo(49.01666667, 2.55, 0)},
+			{Geo(-33.9, 151.2, 0), Geo(51.5, -0.1, 0)},
+			{Geo(0, 0, 0), Geo(0.5, 179.5, 0)},
+			{Geo(-90, 0, 0), Geo(90, 0, 0)},
+		} {
+			p1, p2 := pp[0], pp[1]
+			s12, _, _ := g.Inverse(p1, p2)
+			const n = 7
+			pts := g.Waypoints(p1, p2, n)
+			if len(pts) != n || pts[0] != p1 || pts[n-1] != p2 {
+				t.Fatalf("Waypoints %v: got %v", pp, pts)
+			}
+			for i := 1; i < n; i++ {
+				d, _, _ := g.Inverse(pts[i-1], pts[i])
+				d1, _, _ := g.Inverse(p1, pts[i])
+				if math.Abs(d-s12/(n-1)) > 1e-6 || math.Abs(d1-s12*float64(i)/(n-1)) > 1e-6 {
+					t.Errorf("Waypoints %v: %v: got d=%v d1=%v", pp, i, d, d1)
+				}
+			}
+		}
+	}
+}
@@ -9,12 +9,13 @@ const (
 	DistAndoyer  = iota // Andoyer's approximate distance
 	DistEllipse         // great ellipse distance
 	DistGeodesic        // geodesic distance
+	DistRhumb           // rhumb line distance
 )
 
 // GeoMatrix -- computes an n-by-n symmetric matrix of pairwise distances
 // between the points p[0],...,p[n-1]. The distances are computed on the
 // spheroid `sph` using a predefined method specified by `dist`
-// (DistAndoyer,DistEllipse,DistGeodesic).
+// (DistAndoyer,DistEllipse,DistGeodesic,DistRhumb).
 func GeoMatrix(sph Spheroid, p []Point, dist int) mym.Sym0 {
 	n := len(p)
 	M := mym.NewSym0(n)
@@ -38,7 +39,23 @@ func GeoMatrix(sph Spheroid, p []Point, dist int) mym.Sym0 {
 			}
 		}
 	case DistGeodesic:
-		panic("geomys.GeoMatrix: not implemented: `DistGeodesic`")
+		geod := NewGeodesic(sph)
+		for i, pi := range p {
+			for j := i + 1; j < n; j++ {
+				pj := p[j]
+				geodist, _, _ := geod.Inverse(pi, pj)
+				M.Set(i, j, geodist)
+			}
+		}
+	case DistRhumb:
+		rhumb := NewRhumb(sph)
+		for i, pi := range p {
+			for j := i + 1; j < n; j++ {
+				pj := p[j]
+				geodist, _ := rhumb.Inverse(pi, pj)
+				M.Set(i, j, geodist)
+			}
+		}
 	default:
 		panic("geomys.Geomatrix: domain error: `dist`")
 	}
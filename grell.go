@@ -24,6 +24,54 @@ func (g GreatEllipse) Spheroid() Spheroid {
 // the great ellipse distance `s12` (meters) between the points, also find the azimuths
 // `α1` (degrees) at `p1` and `α2` (degrees) at `p2`.
 func (g GreatEllipse) Inverse(p1, p2 Point) (s12 float64, α1, α2 float64) {
+	inv := g.geninverse(p1, p2)
+	return inv.s12, inv.α1, inv.α2
+}
+
+// InverseExt -- solves the inverse problem for the points `p1` and `p2` as Inverse does,
+// also computes the outputs requested by `mask` (OutReducedLength,OutGeodesicScale,OutArea).
+//
+// The reduced length m12 is the rate of change of the position of `p2` across
+// the great ellipse with respect to the azimuth at `p1`. The scales M12 and M21
+// refer to the pairs of great ellipses that are parallel on the auxiliary sphere
+// of the parametric latitude. The area S12 is computed by Gauss-Legendre quadrature
+// of the difference between the authalic and the parametric latitudes.
+func (g GreatEllipse) InverseExt(p1, p2 Point, mask int) InverseResult {
+	inv := g.geninverse(p1, p2)
+	res := InverseResult{Mask: mask, Dist: inv.s12, Azi1: inv.α1, Azi2: inv.α2}
+	if mask&(OutReducedLength|OutGeodesicScale) != 0 {
+		a, e2 := g.sph.A(), g.sph.E2()
+		w1 := math.Sqrt(1 - e2*inv.cβ1*inv.cβ1)
+		w2 := math.Sqrt(1 - e2*inv.cβ2*inv.cβ2)
+		D1 := 1 - e2*mym.Sq(inv.cβ1*inv.cγ1)
+		D2 := 1 - e2*mym.Sq(inv.cβ2*inv.cγ2)
+		// the stretch across the great ellipse
+		k1 := a * w1 / math.Sqrt(D1)
+		k2 := a * w2 / math.Sqrt(D2)
+		if mask&OutReducedLength != 0 {
+			res.RedLen = inv.sσ12 * k2 * D1 / w1
+		}
+		if mask&OutGeodesicScale != 0 {
+			res.M12 = inv.cσ12 * k2 / k1
+			res.M21 = inv.cσ12 * k1 / k2
+		}
+	}
+	if mask&OutArea != 0 {
+		res.Area = g.area(inv)
+	}
+	return res
+}
+
+// grellinv -- the intermediate results of the inverse problem.
+type grellinv struct {
+	s12, α1, α2          float64
+	sβ1, cβ1, sβ2, cβ2   float64
+	sγ1, cγ1, sγ2, cγ2   float64
+	sγ0, cγ0             float64
+	sσ1, cσ1, sσ12, cσ12 float64
+}
+
+func (g GreatEllipse) geninverse(p1, p2 Point) (inv grellinv) {
 	a, f := g.sph.A(), g.sph.F()
 	f1, e2 := 1-f, g.sph.E2()
 	//
@@ -84,74 +132,48 @@ func (g GreatEllipse) Inverse(p1, p2 Point) (s12 float64, α1, α2 float64) {
 	A1 := a * (1 + ellA1m1f(eps)) * (1 - eps) / (1 + eps)
 	C1 := ellC1f(eps)
 	//
-	s12 = A1 * (math.Atan2(sσ12, cσ12) + (ellSinSeries(sσ2, cσ2, C1[:]) - ellSinSeries(sσ1, cσ1, C1[:])))
-	//
-	α1 = math.Atan2(sγ1, cγ1*math.Sqrt(1-e2*cβ1*cβ1)) * (180 / math.Pi)
-	α2 = math.Atan2(sγ2, cγ2*math.Sqrt(1-e2*cβ2*cβ2)) * (180 / math.Pi)
+	inv.s12 = A1 * (math.Atan2(sσ12, cσ12) + (ellSinSeries(sσ2, cσ2, C1[:]) - ellSinSeries(sσ1, cσ1, C1[:])))
+	inv.α1 = math.Atan2(sγ1, cγ1*math.Sqrt(1-e2*cβ1*cβ1)) * (180 / math.Pi)
+	inv.α2 = math.Atan2(sγ2, cγ2*math.Sqrt(1-e2*cβ2*cβ2)) * (180 / math.Pi)
+	inv.sβ1, inv.cβ1, inv.sβ2, inv.cβ2 = sβ1, cβ1, sβ2, cβ2
+	inv.sγ1, inv.cγ1, inv.sγ2, inv.cγ2 = sγ1, cγ1, sγ2, cγ2
+	inv.sγ0, inv.cγ0 = sγ1*cβ1, cγ0
+	inv.sσ1, inv.cσ1, inv.sσ12, inv.cσ12 = sσ1, cσ1, sσ12, cσ12
 	return
 }
 
+// area -- computes the area between the great ellipse and the equator:
+//
+//	S12 = c²⋅∫sin(ξ)dλ = c²⋅(γ2-γ1) + c²⋅sin(γ0)⋅∫(sin(ξ)-sin(β))/cos²(β)dσ,
+//
+// where c is the authalic radius, ξ is the authalic latitude, β is the parametric
+// latitude, and γ is the azimuth on the auxiliary sphere.
+func (g GreatEllipse) area(inv grellinv) float64 {
+	e2, f1 := g.sph.E2(), 1-g.sph.F()
+	c2 := mym.Sq(g.sph.Rs())
+	qp := authq(e2, 1)
+	//
+	γ12 := math.Atan2(inv.sγ2*inv.cγ1-inv.cγ2*inv.sγ1, inv.cγ2*inv.cγ1+inv.sγ2*inv.sγ1)
+	if inv.sγ0 == 0 || e2 == 0 {
+		return c2 * γ12
+	}
+	//
+	σ1 := math.Atan2(inv.sσ1, inv.cσ1)
+	σ12 := math.Atan2(inv.sσ12, inv.cσ12)
+	var sum float64
+	for i, x := range glx {
+		sσ := math.Sin(σ1 + σ12*(1+x)/2)
+		sβ := inv.cγ0 * sσ
+		c2β := 1 - sβ*sβ
+		sφ, _ := hat(sβ, f1*math.Sqrt(c2β))
+		sum += glw[i] * (authq(e2, sφ)/qp - sβ) / c2β
+	}
+	return c2 * (γ12 + inv.sγ0*sum*σ12/2)
+}
+
 // Direct -- solves the direct problem: given the source point `p1`, the azimuth `α1` (degrees),
 // and the great ellipse distance `s12` (meters), find the target point `p2`, also find the azimuth
 // `α2` (degrees) at `p2`.
 func (g GreatEllipse) Direct(p1 Point, α1 float64, s12 float64) (p2 Point, α2 float64) {
-	a, f := g.sph.A(), g.sph.F()
-	f1, e2 := 1-f, g.sph.E2()
-	//
-	lat1, lon1,_ := p1.Geo()
-	sγ1, cγ1 := mym.SinCosD(α1)
-	sβ1, cβ1 := mym.SinCosD(lat1)
-	sβ1 *= f1
-	sβ1, cβ1 = hat(sβ1, cβ1)
-	sγ1, cγ1 = hat(sγ1*math.Sqrt(1-e2*cβ1*cβ1), cγ1)
-	//
-	sγ0 := sγ1 * cβ1
-	cγ0 := math.Hypot(cγ1, sγ1*sβ1)
-	sσ1 := sβ1
-	sλ1 := sγ0 * sβ1
-	cσ1 := cβ1 * cγ1
-	if sβ1 == 0 && cγ1 == 0 {
-		cσ1 = 1
-	}
-	cλ1 := cσ1
-	sσ1, cσ1 = hat(sσ1, cσ1)
-	//
-	k2 := e2 * cγ0 * cγ0
-	eps := k2 / (2*(1+math.Sqrt(1-k2)) - k2)
-	A1 := a * (1 + ellA1m1f(eps)) * (1 - eps) / (1 + eps)
-	C1a := ellC1f(eps)
-	B11 := ellSinSeries(sσ1, cσ1, C1a[:])
-	s, c := math.Sincos(B11)
-	sτ1 := sσ1*c + cσ1*s
-	cτ1 := cσ1*c - sσ1*s
-	//
-	C1pa := ellC1pf(eps)
-	τ12 := s12 / A1
-	s, c = math.Sincos(τ12)
-	B12 := -ellSinSeries(sτ1*c+cτ1*s, cτ1*c-sτ1*s, C1pa[:])
-	σ12 := τ12 - (B12 - B11)
-	sσ12, cσ12 := math.Sincos(σ12)
-	//
-	sσ2 := sσ1*cσ12 + cσ1*sσ12
-	cσ2 := cσ1*cσ12 - sσ1*sσ12
-	sβ2 := cγ0 * sσ2
-	cβ2 := math.Hypot(sγ0, cγ0*cσ2)
-	//
-	sλ2 := sγ0 * sσ2
-	cλ2 := cσ2
-	sγ2 := sγ0
-	cγ2 := cγ0 * cσ2
-	//
-	lon12 := math.Atan2(sλ2*cλ1-cλ2*sλ1, cλ2*cλ1+sλ2*sλ1) * (180 / math.Pi)
-	lon2 := lon1 + lon12
-	if lon2 <= -180 {
-		lon2 += 2 * 180
-	} else if lon2 > 180 {
-		lon2 -= 2 * 180
-	}
-	//
-	lat2 := math.Atan2(sβ2, f1*cβ2) * (180 / math.Pi)
-	p2 = Geo(lat2, lon2, 0.0)
-	α2 = math.Atan2(sγ2, cγ2*math.Sqrt(1-e2*cβ2*cβ2)) * (180 / math.Pi)
-	return
+	return NewGreatEllipseLine(g, p1, α1).Position(s12)
 }
@@ -0,0 +1,133 @@
+package geomys
+
+import (
+	"github.com/reconditematter/mym"
+	"math"
+)
+
+// GreatEllipseLine -- a great ellipse starting at a given point in a given direction.
+// The setup is done once, so that computing many points along
+// the great ellipse is cheap.
+type GreatEllipseLine struct {
+	g                  GreatEllipse
+	lon1, sγ0, cγ0     float64
+	sσ1, cσ1, sλ1, cλ1 float64
+	A1, B11, sτ1, cτ1  float64
+	C1a, C1pa          [9]float64
+}
+
+// NewGreatEllipseLine -- returns the great ellipse of the solver `g` that starts
+// at the point `p1` with the azimuth `α1` (degrees).
+func NewGreatEllipseLine(g GreatEllipse, p1 Point, α1 float64) GreatEllipseLine {
+	a, f := g.sph.A(), g.sph.F()
+	f1, e2 := 1-f, g.sph.E2()
+	//
+	lat1, lon1, _ := p1.Geo()
+	sγ1, cγ1 := mym.SinCosD(α1)
+	sβ1, cβ1 := mym.SinCosD(lat1)
+	sβ1 *= f1
+	sβ1, cβ1 = hat(sβ1, cβ1)
+	sγ1, cγ1 = hat(sγ1*math.Sqrt(1-e2*cβ1*cβ1), cγ1)
+	//
+	sγ0 := sγ1 * cβ1
+	cγ0 := math.Hypot(cγ1, sγ1*sβ1)
+	sσ1 := sβ1
+	sλ1 := sγ0 * sβ1
+	cσ1 := cβ1 * cγ1
+	if sβ1 == 0 && cγ1 == 0 {
+		cσ1 = 1
+	}
+	cλ1 := cσ1
+	sσ1, cσ1 = hat(sσ1, cσ1)
+	//
+	k2 := e2 * cγ0 * cγ0
+	eps := k2 / (2*(1+math.Sqrt(1-k2)) - k2)
+	A1 := a * (1 + ellA1m1f(eps)) * (1 - eps) / (1 + eps)
+	C1a := ellC1f(eps)
+	B11 := ellSinSeries(sσ1, cσ1, C1a[:])
+	s, c := math.Sincos(B11)
+	sτ1 := sσ1*c + cσ1*s
+	cτ1 := cσ1*c - sσ1*s
+	//
+	return GreatEllipseLine{
+		g: g, lon1: lon1, sγ0: sγ0, cγ0: cγ0,
+		sσ1: sσ1, cσ1: cσ1, sλ1: sλ1, cλ1: cλ1,
+		A1: A1, B11: B11, sτ1: sτ1, cτ1: cτ1,
+		C1a: C1a, C1pa: ellC1pf(eps),
+	}
+}
+
+// GreatEllipse -- returns the great ellipse solver of `l`.
+func (l GreatEllipseLine) GreatEllipse() GreatEllipse {
+	return l.g
+}
+
+// Position -- returns the point `p2` at the distance `s12` (meters)
+// from the start of `l`, also returns the azimuth `α2` (degrees) at `p2`.
+func (l GreatEllipseLine) Position(s12 float64) (p2 Point, α2 float64) {
+	τ12 := s12 / l.A1
+	s, c := math.Sincos(τ12)
+	B12 := -ellSinSeries(l.sτ1*c+l.cτ1*s, l.cτ1*c-l.sτ1*s, l.C1pa[:])
+	σ12 := τ12 - (B12 - l.B11)
+	sσ12, cσ12 := math.Sincos(σ12)
+	sσ2 := l.sσ1*cσ12 + l.cσ1*sσ12
+	cσ2 := l.cσ1*cσ12 - l.sσ1*sσ12
+	return l.position(sσ2, cσ2)
+}
+
+// ArcPosition -- returns the point `p2` at the arc length `σ12` (degrees)
+// on the auxiliary sphere from the start of `l`, also returns the azimuth
+// `α2` (degrees) at `p2` and the distance `s12` (meters) to `p2`.
+func (l GreatEllipseLine) ArcPosition(σ12 float64) (p2 Point, α2, s12 float64) {
+	sσ12, cσ12 := mym.SinCosD(σ12)
+	sσ2 := l.sσ1*cσ12 + l.cσ1*sσ12
+	cσ2 := l.cσ1*cσ12 - l.sσ1*sσ12
+	s12 = l.A1 * (σ12*(math.Pi/180) + (ellSinSeries(sσ2, cσ2, l.C1a[:]) - l.B11))
+	p2, α2 = l.position(sσ2, cσ2)
+	return
+}
+
+// position -- returns the point `p2` and the azimuth `α2` (degrees)
+// at the arc (sσ2,cσ2) on the auxiliary sphere.
+func (l GreatEllipseLine) position(sσ2, cσ2 float64) (p2 Point, α2 float64) {
+	f := l.g.sph.F()
+	f1, e2 := 1-f, l.g.sph.E2()
+	//
+	sβ2 := l.cγ0 * sσ2
+	cβ2 := math.Hypot(l.sγ0, l.cγ0*cσ2)
+	//
+	sλ2 := l.sγ0 * sσ2
+	cλ2 := cσ2
+	sγ2 := l.sγ0
+	cγ2 := l.cγ0 * cσ2
+	//
+	lon12 := math.Atan2(sλ2*l.cλ1-cλ2*l.sλ1, cλ2*l.cλ1+sλ2*l.sλ1) * (180 / math.Pi)
+	lon2 := l.lon1 + lon12
+	if lon2 <= -180 {
+		lon2 += 2 * 180
+	} else if lon2 > 180 {
+		lon2 -= 2 * 180
+	}
+	//
+	lat2 := math.Atan2(sβ2, f1*cβ2) * (180 / math.Pi)
+	p2 = Geo(lat2, lon2, 0.0)
+	α2 = math.Atan2(sγ2, cγ2*math.Sqrt(1-e2*cβ2*cβ2)) * (180 / math.Pi)
+	return
+}
+
+// Waypoints -- returns `n` points evenly spaced in distance along
+// the great ellipse between `p1` and `p2`, including both end points.
+// This function causes a runtime panic when n<2.
+func (g GreatEllipse) Waypoints(p1, p2 Point, n int) []Point {
+	if n < 2 {
+		panic("geomys.GreatEllipse.Waypoints: domain error: `n`")
+	}
+	s12, α1, _ := g.Inverse(p1, p2)
+	l := NewGreatEllipseLine(g, p1, α1)
+	pts := make([]Point, n)
+	pts[0], pts[n-1] = p1, p2
+	for i := 1; i < n-1; i++ {
+		pts[i], _ = l.Position(s12 * float64(i) / float64(n-1))
+	}
+	return pts
+}
@@ -0,0 +1,50 @@
+package geomys
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGreatEllipseLinePosition(t *testing.T) {
+	for _, sph := range []Spheroid{WGS1984(), SRMmax()} {
+		g := NewGreatEllipse(sph)
+		for _, c := range geodTestData {
+			p1, p2 := geodTestPoints(c)
+			s12, α1, α2 := g.Inverse(p1, p2)
+			l := NewGreatEllipseLine(g, p1, α1)
+			q, β2 := l.Position(s12)
+			if d, _, _ := g.Inverse(p2, q); d > 1e-6 || math.Abs(β2-α2) > 1e-9 {
+				t.Errorf("Position %v: got %v %v", c[:6], q, β2)
+			}
+			for _, σ := range []float64{-30, 1e-3, 45, 135, 270} {
+				q, α2, s12 := l.ArcPosition(σ)
+				r, β2 := l.Position(s12)
+				if d, _, _ := g.Inverse(q, r); d > 1e-8 || math.Abs(α2-β2) > 1e-11 {
+					t.Errorf("ArcPosition %v σ=%v: got %v %v, Position %v %v", c[:6], σ, q, α2, r, β2)
+				}
+				r, β2 = g.Direct(p1, α1, s12)
+				if d, _, _ := g.Inverse(q, r); d > 1e-8 || math.Abs(α2-β2) > 1e-11 {
+					t.Errorf("Direct %v s=%v: got %v %v, want %v %v", c[:6], s12, r, β2, q, α2)
+				}
+			}
+		}
+	}
+}
+
+func TestGreatEllipseWaypoints(t *testing.T) {
+	g := NewGreatEllipse(WGS1984())
+	p1, p2 := Geo(-33.9, 151.2, 0), Geo(51.5, -0.1, 0)
+	s12, _, _ := g.Inverse(p1, p2)
+	const n = 11
+	pts := g.Waypoints(p1, p2, n)
+	if len(pts) != n || pts[0] != p1 || pts[n-1] != p2 {
+		t.Fatalf("Waypoints: got %v", pts)
+	}
+	for i := 1; i < n; i++ {
+		d, _, _ := g.Inverse(pts[i-1], pts[i])
+		d1, _, _ := g.Inverse(p1, pts[i])
+		if math.Abs(d-s12/(n-1)) > 1e-6 || math.Abs(d1-s12*float64(i)/(n-1)) > 1e-6 {
+			t.Errorf("Waypoints %v: got d=%v d1=%v", i, d, d1)
+		}
+	}
+}
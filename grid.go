@@ -0,0 +1,160 @@
+package geomys
+
+import (
+	"fmt"
+	"math"
+)
+
+// The linear units (meters per unit) of the grid coordinates.
+const (
+	UnitMetre        = 1.0
+	UnitKilometre    = 1000.0
+	UnitFoot         = 0.3048        // the international foot
+	UnitUSSurveyFoot = 1200.0 / 3937 // the US survey foot
+)
+
+// gridKeys -- the names of the grid parameters in Params().
+var gridKeys = []string{"fe", "fn", "unit", "axis"}
+
+// Grid -- a map projection with a false origin, a linear unit, and an axis order.
+// The grid coordinates are
+//
+//	x = (X+fe)/unit,
+//	y = (Y+fn)/unit,
+//
+// where X,Y are the easting and northing (meters) by the underlying map projection,
+// and the coordinates are swapped to (y,x) for the northing-first axis order.
+type Grid struct {
+	prj          MapProjection
+	par          map[string]float64
+	fe, fn, unit float64
+	swap         bool
+}
+
+// NewGrid -- returns the map projection `prj` with the false easting `fe` (meters),
+// the false northing `fn` (meters), the linear unit `unit` (meters per unit),
+// and the northing-first axis order when `northFirst` is true.
+// This function causes a runtime panic when `prj` is nil, a Grid, or has a parameter
+// named as a grid parameter, when fe,fn are not finite, or when unit∉(0,∞).
+//
+// The grid adds the following parameters to the parameters of `prj`:
+//
+//	fe   -- false easting (meters)
+//	fn   -- false northing (meters)
+//	unit -- linear unit (meters per unit)
+//	axis -- 0 for the easting-first, 1 for the northing-first axis order
+func NewGrid(prj MapProjection, fe, fn, unit float64, northFirst bool) Grid {
+	if prj == nil {
+		panic("geomys.NewGrid: domain error: `prj`")
+	}
+	if _, ok := prj.(Grid); ok {
+		panic("geomys.NewGrid: domain error: `prj`")
+	}
+	par := prj.Params()
+	for _, k := range gridKeys {
+		if _, ok := par[k]; ok {
+			panic("geomys.NewGrid: domain error: `prj`")
+		}
+	}
+	if math.IsNaN(fe) || math.IsInf(fe, 0) {
+		panic("geomys.NewGrid: domain error: `fe`")
+	}
+	if math.IsNaN(fn) || math.IsInf(fn, 0) {
+		panic("geomys.NewGrid: domain error: `fn`")
+	}
+	if !(unit > 0 && unit < math.Inf(1)) {
+		panic("geomys.NewGrid: domain error: `unit`")
+	}
+	axis := 0.0
+	if northFirst {
+		axis = 1
+	}
+	par["fe"], par["fn"], par["unit"], par["axis"] = fe, fn, unit, axis
+	return Grid{prj: prj, par: par, fe: fe, fn: fn, unit: unit, swap: northFirst}
+}
+
+// Projection -- returns the underlying map projection of the grid.
+func (prj Grid) Projection() MapProjection {
+	if prj.par == nil {
+		panic("geomys.Grid.Projection: uninitialized structure")
+	}
+	//
+	return prj.prj
+}
+
+// Spheroid -- returns the spheroid of the map projection.
+func (prj Grid) Spheroid() Spheroid {
+	if prj.par == nil {
+		panic("geomys.Grid.Spheroid: uninitialized structure")
+	}
+	//
+	return prj.prj.Spheroid()
+}
+
+// Params -- returns the parameters of the map projection.
+func (prj Grid) Params() map[string]float64 {
+	if prj.par == nil {
+		panic("geomys.Grid.Params: uninitialized structure")
+	}
+	//
+	par := make(map[string]float64)
+	for k, v := range prj.par {
+		par[k] = v
+	}
+	return par
+}
+
+// Project -- transforms a geographic point from
+// the spheroid into a location on the grid.
+func (prj Grid) Project(p Point) (xy [2]float64) {
+	if prj.par == nil {
+		panic("geomys.Grid.Project: uninitialized structure")
+	}
+	//
+	xy = prj.prj.Project(p)
+	xy[0] = (xy[0] + prj.fe) / prj.unit
+	xy[1] = (xy[1] + prj.fn) / prj.unit
+	if prj.swap {
+		xy[0], xy[1] = xy[1], xy[0]
+	}
+	return
+}
+
+// Unproject -- transforms a location on the grid into
+// a geographic point on the spheroid. Returns an error wrapping
+// ErrOutOfDomain when the location is outside the domain,
+// or when the underlying map projection is not invertible.
+func (prj Grid) Unproject(xy [2]float64) (Point, error) {
+	if prj.par == nil {
+		panic("geomys.Grid.Unproject: uninitialized structure")
+	}
+	//
+	inv, ok := prj.prj.(InvertibleProjection)
+	if !ok {
+		return Point{}, fmt.Errorf("geomys.Grid.Unproject: %w: `xy`", ErrOutOfDomain)
+	}
+	if prj.swap {
+		xy[0], xy[1] = xy[1], xy[0]
+	}
+	xy[0] = xy[0]*prj.unit - prj.fe
+	xy[1] = xy[1]*prj.unit - prj.fn
+	return inv.Unproject(xy)
+}
+
+// splitGridParams -- moves the grid parameters from `par` into a new map.
+func splitGridParams(par map[string]float64) (inner, grid map[string]float64) {
+	inner = make(map[string]float64)
+	for k, v := range par {
+		inner[k] = v
+	}
+	for _, k := range gridKeys {
+		if v, ok := inner[k]; ok {
+			if grid == nil {
+				grid = make(map[string]float64)
+			}
+			grid[k] = v
+			delete(inner, k)
+		}
+	}
+	return inner, grid
+}
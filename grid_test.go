@@ -0,0 +1,69 @@
+package geomys
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestGrid(t *testing.T) {
+	alb := NewAlbers(GRS1980(), 29.5, 45.5, 23, -96)
+	g := NewGrid(alb, 1e6, -5e5, UnitUSSurveyFoot, true)
+	for _, p := range []Point{Geo(23, -96, 0), Geo(40, -75, 0), Geo(25, -120, 0)} {
+		xy, want := g.Project(p), alb.Project(p)
+		if math.Abs(xy[1]*UnitUSSurveyFoot-(want[0]+1e6)) > 1e-9 || math.Abs(xy[0]*UnitUSSurveyFoot-(want[1]-5e5)) > 1e-9 {
+			t.Errorf("Project %v: got %v, want %v", p, xy, want)
+		}
+		q, err := g.Unproject(xy)
+		lat, lon, _ := p.Geo()
+		qlat, qlon, _ := q.Geo()
+		if err != nil || math.Abs(qlat-lat) > 1e-12 || math.Abs(qlon-lon) > 1e-12 {
+			t.Errorf("Unproject %v: got %v, %v", xy, q, err)
+		}
+	}
+	par := g.Params()
+	if len(par) != 8 || par["fe"] != 1e6 || par["fn"] != -5e5 || par["unit"] != UnitUSSurveyFoot || par["axis"] != 1 {
+		t.Errorf("Params: got %v", par)
+	}
+	// the distortion of the underlying projection
+	if d, want := Tissot(g, Geo(35, -80, 0)), Tissot(alb, Geo(35, -80, 0)); d != want {
+		t.Errorf("Tissot: got %+v, want %+v", d, want)
+	}
+}
+
+func TestGridNewProjection(t *testing.T) {
+	prj, err := NewProjection("tmerc", GRS1980(), map[string]float64{"lon0": 173, "k0": 0.9996, "x0": 1.6e6, "y0": 1e7, "axis": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, ok := prj.(Grid)
+	if !ok || g.Params()["unit"] != 1 || g.Params()["fe"] != 0 {
+		t.Fatalf("got %T %v", prj, prj.Params())
+	}
+	// Wellington, NZTM2000 northing first
+	xy := g.Project(Geo(-41.2865, 174.7762, 0))
+	if !(5.42e6 < xy[0] && xy[0] < 5.43e6 && 1.74e6 < xy[1] && xy[1] < 1.75e6) {
+		t.Errorf("Project: got %v", xy)
+	}
+	tests := []struct {
+		par map[string]float64
+		bad string
+	}{
+		{map[string]float64{"unit": 0}, "unit"},
+		{map[string]float64{"axis": 2}, "axis"},
+		{map[string]float64{"fe": math.Inf(1)}, "fe"},
+	}
+	for _, tt := range tests {
+		_, err := NewProjection("eqearth", GRS1980(), tt.par)
+		if !errors.Is(err, ErrInvalidParam) || !strings.Contains(err.Error(), "`"+tt.bad+"`") {
+			t.Errorf("%v: got %v", tt.par, err)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewGrid: no panic on a nested grid")
+		}
+	}()
+	NewGrid(g, 0, 0, 1, false)
+}
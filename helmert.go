@@ -0,0 +1,195 @@
+package geomys
+
+import (
+	"math"
+)
+
+// HelmertConvention -- identifies the sign convention of the rotations of a Helmert transformation.
+type HelmertConvention int
+
+const (
+	HelmertPositionVector  HelmertConvention = iota // the rotations of the position vector (EPSG method 9606)
+	HelmertCoordinateFrame                          // the rotations of the coordinate frame (EPSG method 9607)
+)
+
+// Helmert -- the 7-parameter Helmert (Bursa-Wolf) transformation of the geocentric coordinates
+// from one geodetic datum to another:
+//
+//	X' = T + (1+ds)⋅R⋅X,
+//
+//	    | 1   -rz  ry |
+//	R = | rz   1  -rx |  (the position vector convention),
+//	    |-ry   rx  1  |
+//
+// where T=(tx,ty,tz) are the translations, rx,ry,rz are the rotations, and ds is the scale difference.
+// The coordinate frame convention uses the rotations of the opposite sign.
+//
+// Reference: IOGP Publication 373-7-2, Geomatics Guidance Note number 7, part 2.
+// Coordinate Conversions and Transformations including Formulas (2019).
+type Helmert struct {
+	src, dst Geocentric
+	par      map[string]float64
+	conv     HelmertConvention
+	t        [3]float64
+	m, minv  [3][3]float64 // (1+ds)⋅R and its inverse
+}
+
+// NewHelmert -- returns the Helmert transformation from the datum on the spheroid `src`
+// to the datum on the spheroid `dst` with the translations `t` (meters), the rotations `r`
+// (arc-seconds) in the convention `conv` (HelmertPositionVector,HelmertCoordinateFrame),
+// and the scale difference `ds` (parts per million).
+// This function causes a runtime panic when any of `t`,`r` is not finite, ds∉(-10⁶,10⁶),
+// or `conv` is not a convention.
+//
+// The transformation has the following parameters:
+//
+//	tx,ty,tz -- translations (meters)
+//	rx,ry,rz -- rotations (arc-seconds) in the convention `conv`
+//	ds       -- scale difference (parts per million)
+func NewHelmert(src, dst Spheroid, t, r [3]float64, ds float64, conv HelmertConvention) Helmert {
+	for i := 0; i < 3; i++ {
+		if math.IsNaN(t[i]) || math.IsInf(t[i], 0) {
+			panic("geomys.NewHelmert: domain error: `t`")
+		}
+		if math.IsNaN(r[i]) || math.IsInf(r[i], 0) {
+			panic("geomys.NewHelmert: domain error: `r`")
+		}
+	}
+	if !(-1e6 < ds && ds < 1e6) {
+		panic("geomys.NewHelmert: domain error: `ds`")
+	}
+	if !(conv == HelmertPositionVector || conv == HelmertCoordinateFrame) {
+		panic("geomys.NewHelmert: domain error: `conv`")
+	}
+	par := map[string]float64{"tx": t[0], "ty": t[1], "tz": t[2], "rx": r[0], "ry": r[1], "rz": r[2], "ds": ds}
+	// the rotations (radians) in the position vector convention
+	const sec = math.Pi / (180 * 3600)
+	rx, ry, rz := r[0]*sec, r[1]*sec, r[2]*sec
+	if conv == HelmertCoordinateFrame {
+		rx, ry, rz = -rx, -ry, -rz
+	}
+	s := 1 + ds*1e-6
+	m := [3][3]float64{
+		{s, -s * rz, s * ry},
+		{s * rz, s, -s * rx},
+		{-s * ry, s * rx, s},
+	}
+	return Helmert{src: NewGeocentric(src), dst: NewGeocentric(dst), par: par, conv: conv, t: t, m: m, minv: inv3(m)}
+}
+
+// NAD27ToWGS84 -- returns the transformation from NAD27 (Clarke1866) to WGS84 for the contiguous
+// United States, that is EPSG:1173 (NAD27 to WGS 84 (4)), the accuracy is about 10 meters.
+func NAD27ToWGS84() Helmert {
+	return NewHelmert(Clarke1866(), WGS1984(), [3]float64{-8, 160, 176}, [3]float64{}, 0, HelmertPositionVector)
+}
+
+// ED50ToWGS84 -- returns the transformation from ED50 (International1924) to WGS84 for western Europe,
+// that is EPSG:1133 (ED50 to WGS 84 (1)), the accuracy is about 10 meters.
+func ED50ToWGS84() Helmert {
+	return NewHelmert(International1924(), WGS1984(), [3]float64{-87, -98, -121}, [3]float64{}, 0, HelmertPositionVector)
+}
+
+// Source -- returns the spheroid of the source datum.
+func (h Helmert) Source() Spheroid {
+	if h.par == nil {
+		panic("geomys.Helmert.Source: uninitialized structure")
+	}
+	//
+	return h.src.Spheroid()
+}
+
+// Target -- returns the spheroid of the target datum.
+func (h Helmert) Target() Spheroid {
+	if h.par == nil {
+		panic("geomys.Helmert.Target: uninitialized structure")
+	}
+	//
+	return h.dst.Spheroid()
+}
+
+// Params -- returns the parameters of the transformation.
+func (h Helmert) Params() map[string]float64 {
+	if h.par == nil {
+		panic("geomys.Helmert.Params: uninitialized structure")
+	}
+	//
+	par := make(map[string]float64)
+	for k, v := range h.par {
+		par[k] = v
+	}
+	return par
+}
+
+// Convention -- returns the convention of the rotations (HelmertPositionVector,HelmertCoordinateFrame).
+func (h Helmert) Convention() HelmertConvention {
+	if h.par == nil {
+		panic("geomys.Helmert.Convention: uninitialized structure")
+	}
+	//
+	return h.conv
+}
+
+// Forward -- transforms the geocentric coordinates `xyz` from the source datum to the target datum.
+func (h Helmert) Forward(xyz [3]float64) (xyz2 [3]float64) {
+	if h.par == nil {
+		panic("geomys.Helmert.Forward: uninitialized structure")
+	}
+	//
+	for i := 0; i < 3; i++ {
+		xyz2[i] = h.t[i] + h.m[i][0]*xyz[0] + h.m[i][1]*xyz[1] + h.m[i][2]*xyz[2]
+	}
+	return
+}
+
+// Inverse -- transforms the geocentric coordinates `xyz` from the target datum to the source datum.
+// This is the exact inverse of Forward, rather than Forward with the parameters of the opposite sign.
+func (h Helmert) Inverse(xyz [3]float64) (xyz2 [3]float64) {
+	if h.par == nil {
+		panic("geomys.Helmert.Inverse: uninitialized structure")
+	}
+	//
+	d := [3]float64{xyz[0] - h.t[0], xyz[1] - h.t[1], xyz[2] - h.t[2]}
+	for i := 0; i < 3; i++ {
+		xyz2[i] = h.minv[i][0]*d[0] + h.minv[i][1]*d[1] + h.minv[i][2]*d[2]
+	}
+	return
+}
+
+// ForwardPoint -- transforms the point `p` on the source spheroid into the point on the target spheroid.
+// The point `p` is on the surface of the source spheroid, the height above the target spheroid is dropped.
+func (h Helmert) ForwardPoint(p Point) Point {
+	if h.par == nil {
+		panic("geomys.Helmert.ForwardPoint: uninitialized structure")
+	}
+	//
+	return h.dst.Inverse(h.Forward(h.src.Forward(p)))
+}
+
+// InversePoint -- transforms the point `p` on the target spheroid into the point on the source spheroid.
+// The point `p` is on the surface of the target spheroid, the height above the source spheroid is dropped.
+func (h Helmert) InversePoint(p Point) Point {
+	if h.par == nil {
+		panic("geomys.Helmert.InversePoint: uninitialized structure")
+	}
+	//
+	return h.src.Inverse(h.Inverse(h.dst.Forward(p)))
+}
+
+// inv3 -- returns the inverse of the nonsingular 3×3 matrix `m`.
+func inv3(m [3][3]float64) (inv [3][3]float64) {
+	// the cofactors
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			i1, i2 := (j+1)%3, (j+2)%3
+			j1, j2 := (i+1)%3, (i+2)%3
+			inv[i][j] = m[i1][j1]*m[i2][j2] - m[i1][j2]*m[i2][j1]
+		}
+	}
+	det := m[0][0]*inv[0][0] + m[0][1]*inv[1][0] + m[0][2]*inv[2][0]
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			inv[i][j] /= det
+		}
+	}
+	return
+}
@@ -0,0 +1,73 @@
+package geomys
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHelmert(t *testing.T) {
+	// IOGP 373-7-2, §4.3.3.1, WGS 72 to WGS 84
+	src := NewGeocentric(WGS1972()).Forward(Geo(55, 4, 0))
+	want := [3]float64{3657660.78, 255778.43, 5201387.75}
+	for _, h := range []Helmert{
+		NewHelmert(WGS1972(), WGS1984(), [3]float64{0, 0, 4.5}, [3]float64{0, 0, 0.554}, 0.219, HelmertPositionVector),
+		NewHelmert(WGS1972(), WGS1984(), [3]float64{0, 0, 4.5}, [3]float64{0, 0, -0.554}, 0.219, HelmertCoordinateFrame),
+	} {
+		xyz := h.Forward(src)
+		for i := range xyz {
+			if math.Abs(xyz[i]-want[i]) > 0.01 {
+				t.Errorf("%v Forward: got %v, want %v", h.Convention(), xyz, want)
+				break
+			}
+		}
+		back := h.Inverse(xyz)
+		for i := range back {
+			if math.Abs(back[i]-src[i]) > 1e-6 {
+				t.Errorf("%v Inverse: got %v, want %v", h.Convention(), back, src)
+				break
+			}
+		}
+	}
+}
+
+func TestHelmertPoint(t *testing.T) {
+	// the coordinates computed independently with the published translations
+	// of EPSG:1173 (-8,160,176) and EPSG:1133 (-87,-98,-121)
+	tests := []struct {
+		h        Helmert
+		p        Point
+		lat, lon float64 // the transformed point
+	}{
+		{NAD27ToWGS84(), Geo(39, -98, 0), 39.0000291616, -98.0003485083},
+		{NAD27ToWGS84(), Geo(47, -122, 0), 46.9998090916, -122.0012039998},
+		{ED50ToWGS84(), Geo(52, 13, 0), 51.9992895560, 12.9988946109},
+		{ED50ToWGS84(), Geo(40, -4, 0), 39.9988181548, -4.0012158614},
+	}
+	g := NewGeodesic(WGS1984())
+	for _, tt := range tests {
+		q := tt.h.ForwardPoint(tt.p)
+		if d, _, _ := g.Inverse(q, Geo(tt.lat, tt.lon, 0)); d > 0.01 {
+			t.Errorf("ForwardPoint %v: got %v, %.3f m off", tt.p, q, d)
+		}
+		// the height dropped by ForwardPoint moves the point by millimeters
+		lat, lon, _ := tt.p.Geo()
+		blat, blon, _ := tt.h.InversePoint(q).Geo()
+		if math.Abs(blat-lat) > 1e-7 || math.Abs(blon-lon) > 1e-7 {
+			t.Errorf("InversePoint %v: got %v %v", tt.p, blat, blon)
+		}
+		// the geocentric shift is the translation
+		par := tt.h.Params()
+		xyz := NewGeocentric(tt.h.Source()).Forward(tt.p)
+		xyz2 := tt.h.Forward(xyz)
+		for i, k := range []string{"tx", "ty", "tz"} {
+			if math.Abs(xyz2[i]-xyz[i]-par[k]) > 1e-6 {
+				t.Errorf("Forward %v: got %v, want the shift %v", tt.p, xyz2, par)
+				break
+			}
+		}
+	}
+	par := ED50ToWGS84().Params()
+	if len(par) != 7 || par["tx"] != -87 || par["tz"] != -121 || par["ds"] != 0 {
+		t.Errorf("Params: got %v", par)
+	}
+}
@@ -0,0 +1,137 @@
+package geomys
+
+import (
+	"github.com/reconditematter/mym"
+	"math"
+)
+
+// PathSolver -- a solver of the direct and the inverse problems on a spheroid,
+// such as Geodesic or GreatEllipse.
+type PathSolver interface {
+	InverseSolver
+	// Direct -- returns the point at the distance `s12` (meters) from `p1`
+	// in the direction of the azimuth `α1` (degrees), also returns the azimuth
+	// (degrees) at the point.
+	Direct(p1 Point, α1 float64, s12 float64) (p2 Point, α2 float64)
+}
+
+// Intersection -- an intersection of two lines.
+type Intersection struct {
+	P          Point   // the intersection point
+	X, Y       float64 // the signed distances (meters) to P along the first and the second line
+	Coincident bool    // the lines are coincident, P is one of infinitely many common points
+	Converged  bool    // the iterations converged, otherwise P, X and Y are the last unreliable iterate
+}
+
+const (
+	xMaxit = 100
+	xTol0  = 16 * mym.Epsilon
+)
+
+// xTol -- the tolerance (radians) for the coincidence of the points and the lines.
+var xTol = math.Pi * math.Pow(mym.Epsilon, 0.75)
+
+// Intersect -- finds the intersection of the line that starts at `p1` with the azimuth `α1` (degrees)
+// and the line that starts at `p2` with the azimuth `α2` (degrees), where the lines are
+// the paths of the solver `s` (geodesics or great ellipses). Two such lines intersect
+// infinitely many times, the intersection closest to the starting points
+// (i.e., with the smallest |X|+|Y|) is returned.
+//
+// The lines are coincident when they lie on the same path; in this case the returned point
+// is the common point halfway between `p1` and `p2`. Nearly parallel lines, which are not
+// coincident, intersect about a quarter of the path away from the starting points,
+// the errors of X and Y are inversely proportional to the angle between such lines.
+// The iterations may fail to converge for nearly coincident lines, which is reported
+// by the Converged field.
+func Intersect(s PathSolver, p1 Point, α1 float64, p2 Point, α2 float64) Intersection {
+	return intersect(s, p1, α1, p2, α2, 0, 0)
+}
+
+// IntersectSegments -- finds the intersection of the segment from `p1` to `p2` and the segment
+// from `q1` to `q2`, where the segments are the paths of the solver `s` (geodesics or great ellipses).
+// The intersection closest to the midpoints of the segments is returned, the distances X and Y
+// are measured from `p1` and `q1`. Also returns whether the iterations converged and the intersection
+// lies within both segments.
+func IntersectSegments(s PathSolver, p1, p2, q1, q2 Point) (x Intersection, inside bool) {
+	sp, αp, _ := s.Inverse(p1, p2)
+	sq, αq, _ := s.Inverse(q1, q2)
+	x = intersect(s, p1, αp, q1, αq, sp/2, sq/2)
+	// the distances are accurate to about xTol⋅R
+	tol := 2 * xTol * s.Spheroid().Rs()
+	inside = x.Converged && -tol <= x.X && x.X <= sp+tol && -tol <= x.Y && x.Y <= sq+tol
+	return
+}
+
+// intersect -- iterates the solution of the spherical triangle formed by the current
+// points on the lines at the distances `x` and `y` and their intersection.
+func intersect(s PathSolver, p1 Point, α1 float64, p2 Point, α2 float64, x, y float64) Intersection {
+	R := s.Spheroid().Rs()
+	for i := 0; i < xMaxit; i++ {
+		q1, β1 := s.Direct(p1, α1, x)
+		q2, β2 := s.Direct(p2, α2, y)
+		z, γ1, γ2 := s.Inverse(q1, q2)
+		if z/R < xTol0 {
+			// the current points coincide, the lines too if they are parallel
+			return Intersection{P: q1, X: x, Y: y, Coincident: xcoincident(s, p1, α1, p2, α2, x, y), Converged: true}
+		}
+		dx, dy, ok := xsphere(z/R, β1-γ1, β2-γ2)
+		if !ok {
+			x += R * dx
+			y += R * dy
+			p, _ := s.Direct(p1, α1, x)
+			return Intersection{P: p, X: x, Y: y, Coincident: true, Converged: true}
+		}
+		x += R * dx
+		y += R * dy
+		if math.Abs(dx)+math.Abs(dy) < xTol0 {
+			p, _ := s.Direct(p1, α1, x)
+			return Intersection{P: p, X: x, Y: y, Converged: true}
+		}
+	}
+	// the lines are nearly coincident, so that their intersection is ill-conditioned
+	p, _ := s.Direct(p1, α1, x)
+	return Intersection{P: p, X: x, Y: y}
+}
+
+// xcoincident -- returns whether the lines that meet at the distances `x` and `y` from `p1` and `p2`
+// are coincident. The azimuths at the common point are not used, since they are undefined at a pole,
+// instead the lines are compared one radian away from the common point in both directions of the second line.
+func xcoincident(s PathSolver, p1 Point, α1 float64, p2 Point, α2 float64, x, y float64) bool {
+	R := s.Spheroid().Rs()
+	u, _ := s.Direct(p1, α1, x+R)
+	for _, d := range []float64{R, -R} {
+		v, _ := s.Direct(p2, α2, y+d)
+		if z, _, _ := s.Inverse(u, v); z/R < xTol {
+			return true
+		}
+	}
+	return false
+}
+
+// xsphere -- solves the intersection problem on the unit sphere: the points X and Y
+// are at the distance `z` (radians), the lines through X and Y make the angles
+// `δx` and `δy` (degrees) with the great circle from X to Y. Returns the distances
+// `dx` and `dy` (radians) from X and Y to the closest intersection, `ok` is false
+// when the lines are coincident, then `dx` and `dy` give the common point halfway
+// between X and Y.
+func xsphere(z, δx, δy float64) (dx, dy float64, ok bool) {
+	sz, cz := math.Sincos(z)
+	sx, cx := mym.SinCosD(δx)
+	sy, cy := mym.SinCosD(δy)
+	// the intersection point Z=(Zx,Zy,Zz) for X=(1,0,0) and Y=(cos(z),sin(z),0)
+	zx := sx*cy - cx*cz*sy
+	zy := -sz * sy
+	if math.Hypot(zx, zy) < xTol {
+		// the lines are on the same great circle: X+dx⋅cos(δx) = z/2 = z+dy⋅cos(δy)
+		return math.Copysign(z/2, cx), -math.Copysign(z/2, cy), false
+	}
+	dx = math.Atan2(-sz*sy, zx)
+	dy = math.Atan2(-sz*sx, cz*sx*cy-cx*sy)
+	// the antipodal intersection -Z
+	dxa := math.Atan2(sz*sy, -zx)
+	dya := math.Atan2(sz*sx, -(cz*sx*cy - cx*sy))
+	if math.Abs(dxa)+math.Abs(dya) < math.Abs(dx)+math.Abs(dy) {
+		dx, dy = dxa, dya
+	}
+	return dx, dy, true
+}
@@ -0,0 +1,167 @@
+package geomys
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// xSolvers -- the geodesic and the great ellipse solvers on WGS1984 and SRMmax.
+func xSolvers() map[string]PathSolver {
+	return map[string]PathSolver{
+		"Geodesic WGS1984":     NewGeodesic(WGS1984()),
+		"Geodesic SRMmax":      NewGeodesic(SRMmax()),
+		"GreatEllipse WGS1984": NewGreatEllipse(WGS1984()),
+		"GreatEllipse SRMmax":  NewGreatEllipse(SRMmax()),
+	}
+}
+
+// xCheck -- checks that the point `x.P` lies on both lines at the distances `x.X` and `x.Y`.
+func xCheck(t *testing.T, name string, s PathSolver, p1 Point, α1 float64, p2 Point, α2 float64, x Intersection) {
+	t.Helper()
+	if !x.Converged {
+		t.Errorf("%s: the iterations did not converge", name)
+		return
+	}
+	for _, l := range []struct {
+		p    Point
+		α, d float64
+	}{{p1, α1, x.X}, {p2, α2, x.Y}} {
+		q, _ := s.Direct(l.p, l.α, l.d)
+		if d, _, _ := s.Inverse(q, x.P); d > 1e-6 {
+			t.Errorf("%s: %v is %v m off the line from %v", name, x.P, d, l.p)
+		}
+		// the distance along the line is the shortest distance within a half of the path
+		if d, _, _ := s.Inverse(l.p, x.P); math.Abs(l.d) < 1.5e7 && math.Abs(d-math.Abs(l.d)) > 1e-6 {
+			t.Errorf("%s: the distance from %v is %v, want %v", name, l.p, d, math.Abs(l.d))
+		}
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	cases := []struct {
+		lat1, lon1, α1, lat2, lon2, α2 float64
+	}{
+		{0, 0, 45, 0, 10, -45},
+		{40.6, -73.8, 53.5, 51.5, -0.1, 260},
+		{-33.9, 151.2, 100, -37.8, 145, 80},
+		{60, 30, 0, -10, 170, 10},
+		// nearly parallel lines intersect far away from the starting points
+		{10, 0, 45, 10, 1e-3, 45},
+		{-20, 40, 170, -20.001, 40, 170},
+	}
+	for name, s := range xSolvers() {
+		for _, c := range cases {
+			p1, p2 := Geo(c.lat1, c.lon1, 0), Geo(c.lat2, c.lon2, 0)
+			x := Intersect(s, p1, c.α1, p2, c.α2)
+			if x.Coincident {
+				t.Errorf("%s %v: got coincident lines", name, c)
+			}
+			xCheck(t, name, s, p1, c.α1, p2, c.α2, x)
+		}
+	}
+}
+
+func TestIntersectMeridians(t *testing.T) {
+	// the nearly parallel meridians intersect at the pole,
+	// the error of X,Y grows as the angle between the lines decreases
+	for name, s := range xSolvers() {
+		qm, _, _ := NewGeodesic(s.Spheroid()).Inverse(Geo(0, 0, 0), Geo(90, 0, 0))
+		for _, dlon := range []float64{1, 1e-3} {
+			x := Intersect(s, Geo(0, 0, 0), 0, Geo(0, dlon, 0), 0)
+			lat, _, _ := x.P.Geo()
+			tol := 1e-9 / dlon
+			if x.Coincident || math.Abs(lat-90) > tol || math.Abs(x.X-qm) > 1e5*tol || math.Abs(x.Y-qm) > 1e5*tol {
+				t.Errorf("%s dlon=%v: got %+v", name, dlon, x)
+			}
+		}
+	}
+}
+
+func TestIntersectNearlyParallel(t *testing.T) {
+	// the nearly parallel lines on the flattest spheroid
+	cases := []struct {
+		lat1, lon1, α1, lat2, lon2, α2 float64
+	}{
+		{10, 0, 45, 10, 1e-6, 45},
+		{0, 0, 30, 0, 1e-5, 30},
+		{30, 0, 60, 30.000001, 0, 60},
+		{-40, 10, 80, -40, 10.00001, 80},
+		{30, 0, 0, 30, 1e-7, 1e-7},
+	}
+	for _, s := range []PathSolver{NewGeodesic(SRMmax()), NewGreatEllipse(SRMmax())} {
+		name := fmt.Sprintf("%T", s)
+		for _, c := range cases {
+			p1, p2 := Geo(c.lat1, c.lon1, 0), Geo(c.lat2, c.lon2, 0)
+			x := Intersect(s, p1, c.α1, p2, c.α2)
+			if x.Coincident {
+				t.Errorf("%s %v: got coincident lines", name, c)
+			}
+			xCheck(t, name, s, p1, c.α1, p2, c.α2, x)
+		}
+	}
+	// the lines a few millimeters apart with the angle of 10⁻⁹ degrees between them
+	s := NewGreatEllipse(SRMmax())
+	p1, p2 := Geo(-67.43129733314501, -171.31272427384928, 0), Geo(-67.43129733456068, -171.3127242824923, 0)
+	if x := Intersect(s, p1, -153.79417667152765, p2, -153.7941766718742); x.Converged {
+		t.Errorf("GreatEllipse: got %+v", x)
+	}
+}
+
+func TestIntersectCoincident(t *testing.T) {
+	for name, s := range xSolvers() {
+		p1 := Geo(-10, 20, 0)
+		for _, α1 := range []float64{0, 30, 90, -135} {
+			p2, β := s.Direct(p1, α1, 1e6)
+			// the same direction and the opposite direction
+			for _, α2 := range []float64{β, β + 180} {
+				x := Intersect(s, p1, α1, p2, α2)
+				if !x.Coincident {
+					t.Errorf("%s α1=%v α2=%v: got %+v", name, α1, α2, x)
+					continue
+				}
+				xCheck(t, name, s, p1, α1, p2, α2, x)
+				if math.Abs(x.X-5e5) > 1e-3 || math.Abs(math.Abs(x.Y)-5e5) > 1e-3 {
+					t.Errorf("%s α1=%v α2=%v: got X=%v Y=%v", name, α1, α2, x.X, x.Y)
+				}
+			}
+		}
+		// the same starting point in the same direction
+		if x := Intersect(s, p1, 30, p1, 30); !x.Coincident || x.X != 0 || x.Y != 0 {
+			t.Errorf("%s: got %+v", name, x)
+		}
+	}
+}
+
+func TestIntersectSegments(t *testing.T) {
+	cases := []struct {
+		p1, p2, q1, q2 Point
+		inside         bool
+	}{
+		{Geo(0, 0, 0), Geo(10, 10, 0), Geo(10, 0, 0), Geo(0, 10, 0), true},
+		{Geo(40.6, -73.8, 0), Geo(51.5, -0.1, 0), Geo(60, -40, 0), Geo(30, -30, 0), true},
+		{Geo(0, 0, 0), Geo(10, 10, 0), Geo(10, 20, 0), Geo(20, 10, 0), false},
+		{Geo(0, 0, 0), Geo(10, 10, 0), Geo(-5, 5, 0), Geo(-5, 15, 0), false},
+		// the end point of a segment on the other segment
+		{Geo(0, 0, 0), Geo(0, 10, 0), Geo(0, 5, 0), Geo(10, 5, 0), true},
+	}
+	for name, s := range xSolvers() {
+		for i, c := range cases {
+			x, inside := IntersectSegments(s, c.p1, c.p2, c.q1, c.q2)
+			_, αp, _ := s.Inverse(c.p1, c.p2)
+			_, αq, _ := s.Inverse(c.q1, c.q2)
+			xCheck(t, name, s, c.p1, αp, c.q1, αq, x)
+			if inside != c.inside || x.Coincident {
+				t.Errorf("%s %v: got %+v inside=%v", name, i, x, inside)
+			}
+		}
+		// the overlapping segments on the same path
+		p1, p2 := Geo(0, 0, 0), Geo(20, 30, 0)
+		sp, α, _ := s.Inverse(p1, p2)
+		q1, _ := s.Direct(p1, α, sp/2)
+		x, inside := IntersectSegments(s, p1, p2, q1, p2)
+		if !x.Coincident || !inside {
+			t.Errorf("%s overlapping: got %+v inside=%v", name, x, inside)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package geomys
+
+// The outputs of the extended inverse problem in addition to
+// the distance and the azimuths, which are always computed.
+const (
+	OutReducedLength = 1 << iota // reduced length m12
+	OutGeodesicScale             // geodesic scales M12 and M21
+	OutArea                      // area S12 between the path and the equator
+	OutAll           = OutReducedLength | OutGeodesicScale | OutArea
+)
+
+// InverseResult -- the extended solution of the inverse problem
+// between two points p1 and p2.
+type InverseResult struct {
+	Mask       int     // the requested outputs (OutReducedLength,OutGeodesicScale,OutArea)
+	Dist       float64 // distance s12 (meters)
+	Azi1, Azi2 float64 // azimuths α1 at p1 and α2 at p2 (degrees)
+	RedLen     float64 // reduced length m12 (meters)
+	M12, M21   float64 // geodesic scales M12 and M21 (dimensionless)
+	Area       float64 // area S12 between the path and the equator (square meters)
+}
+
+// InverseSolver -- a solver of the inverse problem on a spheroid.
+type InverseSolver interface {
+	// Spheroid -- returns the spheroid of the solver.
+	Spheroid() Spheroid
+	// Inverse -- returns the distance (meters) between two points
+	// and the azimuths (degrees) at the points.
+	Inverse(p1, p2 Point) (s12 float64, α1, α2 float64)
+	// InverseExt -- returns the distance, the azimuths, and the outputs
+	// requested by the mask (OutReducedLength,OutGeodesicScale,OutArea).
+	InverseExt(p1, p2 Point, mask int) InverseResult
+}
@@ -0,0 +1,86 @@
+package geomys
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGeodesicInverseExt(t *testing.T) {
+	g := NewGeodesic(WGS1984())
+	for _, c := range geodTestData {
+		p1, p2 := geodTestPoints(c)
+		res := g.InverseExt(p1, p2, OutAll)
+		if math.Abs(res.Dist-c[6]) > 1e-8 || math.Abs(res.Azi1-c[2]) > 1e-12 || math.Abs(res.Azi2-c[5]) > 1e-12 {
+			t.Errorf("InverseExt %v: got %+v", c[:6], res)
+		}
+		if math.Abs(res.RedLen-c[8]) > 1e-8 || math.Abs(res.M12-c[9]) > 1e-15 || math.Abs(res.M21-c[10]) > 1e-15 {
+			t.Errorf("InverseExt %v: got m12=%v M12=%v M21=%v", c[:6], res.RedLen, res.M12, res.M21)
+		}
+		if math.Abs(res.Area-c[11]) > 0.1 {
+			t.Errorf("InverseExt %v: got S12=%v", c[:6], res.Area)
+		}
+	}
+	// GeodSolve80: the scales on the equator and the area of a zero length geodesic at a pole
+	res := g.InverseExt(Geo(0, 0, 0), Geo(0, 90, 0), OutGeodesicScale)
+	if math.Abs(res.M12+0.00528427534) > 0.5e-10 || math.Abs(res.M21+0.00528427534) > 0.5e-10 {
+		t.Errorf("InverseExt equator: got %+v", res)
+	}
+	res = g.InverseExt(Geo(90, 0, 0), Geo(90, 180, 0), OutAll)
+	if res.Dist != 0 || res.RedLen != 0 || res.M12 != 1 || res.M21 != 1 || math.Abs(res.Area-127516405431022.0) > 0.5 {
+		t.Errorf("InverseExt pole: got %+v", res)
+	}
+}
+
+func TestGreatEllipseInverseExt(t *testing.T) {
+	// the great ellipses on a sphere are the geodesics
+	sph := NewSphere(6371000)
+	g, geod := NewGreatEllipse(sph), NewGeodesic(sph)
+	for _, c := range geodTestData {
+		p1, p2 := geodTestPoints(c)
+		res, want := g.InverseExt(p1, p2, OutAll), geod.InverseExt(p1, p2, OutAll)
+		if math.Abs(res.Dist-want.Dist) > 1e-6 || math.Abs(res.RedLen-want.RedLen) > 1e-6 ||
+			math.Abs(res.M12-want.M12) > 1e-12 || math.Abs(res.M21-want.M21) > 1e-12 || math.Abs(res.Area-want.Area) > 1 {
+			t.Errorf("InverseExt %v: got %+v, want %+v", c[:6], res, want)
+		}
+	}
+	// the reduced length is the rate of change of the position of p2 across the path
+	// with respect to the azimuth at p1
+	g = NewGreatEllipse(WGS1984())
+	for _, c := range geodTestData {
+		p1, p2 := geodTestPoints(c)
+		res := g.InverseExt(p1, p2, OutReducedLength)
+		const δ = 1e-6
+		q1, _ := NewGreatEllipseLine(g, p1, res.Azi1-δ).Position(res.Dist)
+		q2, _ := NewGreatEllipseLine(g, p1, res.Azi1+δ).Position(res.Dist)
+		d, _, _ := g.Inverse(q1, q2)
+		if m12 := d / (2 * δ * math.Pi / 180); math.Abs(m12-math.Abs(res.RedLen)) > 1e-4*math.Abs(res.RedLen) {
+			t.Errorf("InverseExt %v: got m12=%v, want %v", c[:6], res.RedLen, m12)
+		}
+	}
+}
+
+func TestInverseExtMask(t *testing.T) {
+	p1, p2 := Geo(40.6, -73.8, 0), Geo(49.01666667, 2.55, 0)
+	for _, solver := range []InverseSolver{NewGeodesic(WGS1984()), NewGreatEllipse(WGS1984())} {
+		all := solver.InverseExt(p1, p2, OutAll)
+		s12, α1, α2 := solver.Inverse(p1, p2)
+		if all.Dist != s12 || all.Azi1 != α1 || all.Azi2 != α2 || all.RedLen == 0 || all.M12 == 0 || all.M21 == 0 || all.Area == 0 {
+			t.Errorf("%T OutAll: got %+v", solver, all)
+		}
+		for _, mask := range []int{0, OutReducedLength, OutGeodesicScale, OutArea, OutReducedLength | OutArea} {
+			want := InverseResult{Mask: mask, Dist: all.Dist, Azi1: all.Azi1, Azi2: all.Azi2}
+			if mask&OutReducedLength != 0 {
+				want.RedLen = all.RedLen
+			}
+			if mask&OutGeodesicScale != 0 {
+				want.M12, want.M21 = all.M12, all.M21
+			}
+			if mask&OutArea != 0 {
+				want.Area = all.Area
+			}
+			if res := solver.InverseExt(p1, p2, mask); res != want {
+				t.Errorf("%T mask=%v: got %+v, want %+v", solver, mask, res, want)
+			}
+		}
+	}
+}
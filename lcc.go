@@ -0,0 +1,186 @@
+package geomys
+
+import (
+	"fmt"
+	"github.com/reconditematter/mym"
+	"math"
+)
+
+// LambertConformalConic -- Lambert conformal conic map projection.
+type LambertConformalConic struct {
+	sph          Spheroid
+	par          map[string]float64
+	n, c, ρ0, λ0 float64
+}
+
+// NewLambertConformalConic -- returns a new Lambert conformal conic map projection
+// with two standard parallels based on the spheroid `sph`.
+// This function causes a runtime panic when lat1,lat2∉(-90,90), lat1=-lat2,
+// lat0∉[-90,90], lat0 is the pole opposite to the apex of the cone, or lon0∉[-180,180].
+//
+// The projection has the following parameters:
+//
+//	lat1 -- latitude of the 1st standard parallel
+//	lat2 -- latitude of the 2nd standard parallel
+//	lat0 -- latitude of the false origin
+//	lon0 -- longitude of the false origin
+//	x0   -- false easting
+//	y0   -- false northing
+func NewLambertConformalConic(sph Spheroid, lat1, lat2, lat0, lon0, x0, y0 float64) LambertConformalConic {
+	if !(-90 < lat1 && lat1 < 90) {
+		panic("geomys.NewLambertConformalConic: domain error: `lat1`")
+	}
+	if !(-90 < lat2 && lat2 < 90) || lat1 == -lat2 {
+		panic("geomys.NewLambertConformalConic: domain error: `lat2`")
+	}
+	par := map[string]float64{"lat1": lat1, "lat2": lat2, "lat0": lat0, "lon0": lon0, "x0": x0, "y0": y0}
+	prj := LambertConformalConic{sph: sph, par: par}
+	prj.inilcc("geomys.NewLambertConformalConic", lat1, lat2, 1)
+	return prj
+}
+
+// NewLambertConformalConic1SP -- returns a new Lambert conformal conic map projection
+// with one standard parallel based on the spheroid `sph`.
+// This function causes a runtime panic when lat0∉(-90,90), lat0=0, lon0∉[-180,180], or k0≤0.
+//
+// The projection has the following parameters:
+//
+//	lat0 -- latitude of the natural origin, that is the standard parallel
+//	lon0 -- longitude of the natural origin
+//	k0   -- scale factor at the natural origin
+//	x0   -- false easting
+//	y0   -- false northing
+func NewLambertConformalConic1SP(sph Spheroid, lat0, lon0, k0, x0, y0 float64) LambertConformalConic {
+	if !(-90 < lat0 && lat0 < 90) || lat0 == 0 {
+		panic("geomys.NewLambertConformalConic1SP: domain error: `lat0`")
+	}
+	if !(k0 > 0 && k0 < math.Inf(1)) {
+		panic("geomys.NewLambertConformalConic1SP: domain error: `k0`")
+	}
+	par := map[string]float64{"lat0": lat0, "lon0": lon0, "k0": k0, "x0": x0, "y0": y0}
+	prj := LambertConformalConic{sph: sph, par: par}
+	prj.inilcc("geomys.NewLambertConformalConic1SP", lat0, lat0, k0)
+	return prj
+}
+
+// Spheroid -- returns the spheroid of the map projection.
+func (prj LambertConformalConic) Spheroid() Spheroid {
+	if prj.par == nil {
+		panic("geomys.LambertConformalConic.Spheroid: uninitialized structure")
+	}
+	//
+	return prj.sph
+}
+
+// Params -- returns the parameters of the map projection.
+func (prj LambertConformalConic) Params() map[string]float64 {
+	if prj.par == nil {
+		panic("geomys.LambertConformalConic.Params: uninitialized structure")
+	}
+	//
+	par := make(map[string]float64)
+	for k, v := range prj.par {
+		par[k] = v
+	}
+	return par
+}
+
+// Project -- transforms a geographic point from
+// the spheroid into a location on the plane.
+func (prj LambertConformalConic) Project(p Point) (xy [2]float64) {
+	xy, _, _ = prj.ProjectExt(p)
+	return
+}
+
+// ProjectExt -- transforms a geographic point from the spheroid into a location on the plane,
+// also returns the meridian convergence `γ` (degrees), that is the bearing of grid north
+// clockwise from true north, and the point scale `k`. The poles have k=+∞.
+func (prj LambertConformalConic) ProjectExt(p Point) (xy [2]float64, γ, k float64) {
+	if prj.par == nil {
+		panic("geomys.LambertConformalConic.ProjectExt: uninitialized structure")
+	}
+	//
+	lat, lon, _ := p.Geo()
+	ρ := prj.rholcc(lat)
+	θ := prj.n * angNormalize(lon-prj.λ0)
+	sinθ, cosθ := mym.SinCosD(θ)
+	xy[0] = ρ*sinθ + prj.par["x0"]
+	xy[1] = prj.ρ0 - ρ*cosθ + prj.par["y0"]
+	// k = n⋅ρ/(N(φ)⋅cos(φ)), γ = θ
+	_, cosφ := mym.SinCosD(lat)
+	k = math.Inf(1)
+	if cosφ != 0 {
+		k = prj.n * ρ / (prj.sph.PrimeVerticalRadius(lat) * cosφ)
+	}
+	γ = θ
+	return
+}
+
+// Unproject -- transforms a location on the plane into
+// a geographic point on the spheroid. Returns an error wrapping
+// ErrOutOfDomain when `xy` is outside the image of the spheroid.
+func (prj LambertConformalConic) Unproject(xy [2]float64) (Point, error) {
+	if prj.par == nil {
+		panic("geomys.LambertConformalConic.Unproject: uninitialized structure")
+	}
+	//
+	x, y := xy[0]-prj.par["x0"], prj.ρ0-(xy[1]-prj.par["y0"])
+	if prj.n < 0 {
+		x, y = -x, -y
+	}
+	if !(mym.FiniteIs(x) && mym.FiniteIs(y)) {
+		return Point{}, fmt.Errorf("geomys.LambertConformalConic.Unproject: %w: `xy`", ErrOutOfDomain)
+	}
+	// |ρ| = |c|⋅exp(-n⋅ψ), where ψ is the isometric latitude
+	ρ := math.Hypot(x, y)
+	ψ := -math.Log(ρ/math.Abs(prj.c)) / prj.n * (180 / math.Pi)
+	φ := prj.sph.GeoLatIsometric(ψ)
+	if math.Abs(φ) == 90 {
+		// the apex of the cone
+		return Geo(φ, prj.λ0, 0.0), nil
+	}
+	λ := atan2d(x, y) / prj.n
+	if !(math.Abs(λ) <= 180) {
+		// allow for the round-off in θ/n at the antimeridian
+		if !(math.Abs(λ) <= 180*(1+8*mym.Epsilon/math.Abs(prj.n))) {
+			return Point{}, fmt.Errorf("geomys.LambertConformalConic.Unproject: %w: `xy`", ErrOutOfDomain)
+		}
+		λ = math.Copysign(180, λ)
+	}
+	return Geo(φ, angNormalize(prj.λ0+λ), 0.0), nil
+}
+
+func (prj *LambertConformalConic) inilcc(fn string, lat1, lat2, k0 float64) {
+	lat0 := prj.par["lat0"]
+	lon0 := prj.par["lon0"]
+	if !(-90 <= lat0 && lat0 <= 90) {
+		panic(fn + ": domain error: `lat0`")
+	}
+	if !(-180 <= lon0 && lon0 <= 180) {
+		panic(fn + ": domain error: `lon0`")
+	}
+	//
+	sinφ1, cosφ1 := mym.SinCosD(lat1)
+	_, cosφ2 := mym.SinCosD(lat2)
+	ψ1 := prj.sph.IsometricLat(lat1) * (math.Pi / 180)
+	ψ2 := prj.sph.IsometricLat(lat2) * (math.Pi / 180)
+	// the radii (meters) of the standard parallels
+	m1 := prj.sph.PrimeVerticalRadius(lat1) * cosφ1
+	m2 := prj.sph.PrimeVerticalRadius(lat2) * cosφ2
+	//
+	n := sinφ1
+	if lat1 != lat2 {
+		n = math.Log(m1/m2) / (ψ2 - ψ1)
+	}
+	// ρ(φ) = c⋅exp(-n⋅ψ(φ)), ρ(φ1) = k0⋅m1/n
+	c := k0 * m1 / n * math.Exp(n*ψ1)
+	prj.n, prj.c, prj.λ0 = n, c, lon0
+	prj.ρ0 = prj.rholcc(lat0)
+	if math.IsInf(prj.ρ0, 0) {
+		panic(fn + ": domain error: `lat0`")
+	}
+}
+
+func (prj LambertConformalConic) rholcc(lat float64) float64 {
+	return prj.c * math.Exp(-prj.n*prj.sph.IsometricLat(lat)*(math.Pi/180))
+}
@@ -0,0 +1,68 @@
+package geomys
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestLambertConformalConicKnown(t *testing.T) {
+	// Snyder, J.P. Map Projections: A Working Manual (1987), p.296
+	prj := NewLambertConformalConic(Clarke1866(), 33, 45, 23, -96, 0, 0)
+	xy := prj.Project(Geo(35, -75, 0))
+	if math.Abs(xy[0]-1894410.9) > 0.1 || math.Abs(xy[1]-1564649.5) > 0.1 {
+		t.Errorf("Project 2SP: got %v", xy)
+	}
+	// IOGP Guidance Note 7-2, Jamaica National Grid
+	prj = NewLambertConformalConic1SP(Clarke1866(), 18, -77, 1, 250000, 150000)
+	xy = prj.Project(Geo(17+55.0/60+55.80/3600, -(76 + 56.0/60 + 37.26/3600), 0))
+	if math.Abs(xy[0]-255966.58) > 0.01 || math.Abs(xy[1]-142493.51) > 0.01 {
+		t.Errorf("Project 1SP: got %v", xy)
+	}
+}
+
+func TestLambertConformalConicRoundTrip(t *testing.T) {
+	sph := WGS1984()
+	prjs := []LambertConformalConic{
+		NewLambertConformalConic(sph, 33, 45, 23, -96, 0, 0),
+		NewLambertConformalConic(sph, -18, -36, 0, 132, 5e5, 1e7),
+		NewLambertConformalConic(sph, 50, 50, 90, 180, 0, 0),
+		NewLambertConformalConic1SP(sph, 18, -77, 1, 250000, 150000),
+		NewLambertConformalConic1SP(sph, -45, 10, 0.9999, 0, 0),
+	}
+	for _, prj := range prjs {
+		n := prj.n
+		for lat := -85.0; lat <= 85; lat += 5 {
+			if lat*n < -60 {
+				// far from the apex the projection is ill-conditioned
+				continue
+			}
+			for lon := -180.0; lon <= 180; lon += 15 {
+				p, err := prj.Unproject(prj.Project(Geo(lat, lon, 0)))
+				if err != nil {
+					t.Fatalf("%v (%v,%v): %v", prj.Params(), lat, lon, err)
+				}
+				lat2, lon2, _ := p.Geo()
+				dlon, _ := angDiff(lon, lon2)
+				if math.Abs(lat2-lat) > 1e-9 || math.Abs(dlon) > 1e-9 {
+					t.Errorf("%v (%v,%v): got (%v,%v)", prj.Params(), lat, lon, lat2, lon2)
+				}
+			}
+		}
+		// the apex of the cone
+		pole := math.Copysign(90, n)
+		p, err := prj.Unproject(prj.Project(Geo(pole, 0, 0)))
+		if lat, _, _ := p.Geo(); err != nil || lat != pole {
+			t.Errorf("%v: pole got %v, %v", prj.Params(), lat, err)
+		}
+	}
+}
+
+func TestLambertConformalConicOutOfDomain(t *testing.T) {
+	prj := NewLambertConformalConic(WGS1984(), 33, 45, 23, -96, 0, 0)
+	for _, xy := range [][2]float64{{0, 1e9}, {math.NaN(), 0}, {math.Inf(-1), 0}} {
+		if _, err := prj.Unproject(xy); !errors.Is(err, ErrOutOfDomain) {
+			t.Errorf("Unproject(%v): got %v", xy, err)
+		}
+	}
+}
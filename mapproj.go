@@ -1,5 +1,13 @@
 package geomys
 
+import (
+	"errors"
+)
+
+// ErrOutOfDomain -- the error returned when a location on the plane
+// is outside the domain of the inverse of a map projection.
+var ErrOutOfDomain = errors.New("location outside the projection domain")
+
 // MapProjection -- a method to transfrom a globe's surface into a plane.
 type MapProjection interface {
 	// Spheroid -- returns the spheroid of the map projection.
@@ -10,3 +18,23 @@ type MapProjection interface {
 	// the spheroid into a location on the plane.
 	Project(Point) [2]float64
 }
+
+// ConformalProjection -- a conformal map projection that provides
+// the meridian convergence and the point scale in closed form.
+type ConformalProjection interface {
+	MapProjection
+	// ProjectExt -- transforms a geographic point from the spheroid into a location on the plane,
+	// also returns the meridian convergence (degrees), that is the bearing of grid north
+	// clockwise from true north, and the point scale.
+	ProjectExt(Point) ([2]float64, float64, float64)
+}
+
+// InvertibleProjection -- a map projection that can transform
+// locations on the plane back into geographic points.
+type InvertibleProjection interface {
+	MapProjection
+	// Unproject -- transforms a location on the plane into
+	// a geographic point on the spheroid. Returns an error wrapping
+	// ErrOutOfDomain when the location is outside the domain.
+	Unproject([2]float64) (Point, error)
+}
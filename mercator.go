@@ -0,0 +1,173 @@
+package geomys
+
+import (
+	"fmt"
+	"github.com/reconditematter/mym"
+	"math"
+)
+
+// Mercator -- Mercator map projection on the spheroid.
+type Mercator struct {
+	sph Spheroid
+	par map[string]float64
+	ak0 float64
+}
+
+// NewMercator -- returns a new Mercator map projection based on the spheroid `sph`.
+// This function causes a runtime panic when lat1∉(-90,90) or lon0∉[-180,180].
+//
+// The projection has the following parameters:
+//
+//	lat1 -- latitude of true scale
+//	lon0 -- longitude of the central meridian
+//	x0   -- false easting
+//	y0   -- false northing
+func NewMercator(sph Spheroid, lat1, lon0, x0, y0 float64) Mercator {
+	if !(-90 < lat1 && lat1 < 90) {
+		panic("geomys.NewMercator: domain error: `lat1`")
+	}
+	if !(-180 <= lon0 && lon0 <= 180) {
+		panic("geomys.NewMercator: domain error: `lon0`")
+	}
+	par := map[string]float64{"lat1": lat1, "lon0": lon0, "x0": x0, "y0": y0}
+	// the scale factor on the equator k0 = N(φ1)⋅cos(φ1)/a
+	_, cosφ1 := mym.SinCosD(lat1)
+	ak0 := sph.PrimeVerticalRadius(lat1) * cosφ1
+	return Mercator{sph: sph, par: par, ak0: ak0}
+}
+
+// mercLat1 -- returns the latitude of true scale of the Mercator map projection
+// with the scale factor `k0` on the equator. Returns false when k0∉(0,1].
+func mercLat1(sph Spheroid, k0 float64) (float64, bool) {
+	if !(0 < k0 && k0 <= 1) {
+		return 0, false
+	}
+	// k0 = cos(φ1)/√(1-e²⋅sin²φ1)
+	e2 := sph.E2()
+	return math.Asin(math.Sqrt((1-k0*k0)/(1-e2*k0*k0))) * (180 / math.Pi), true
+}
+
+// Spheroid -- returns the spheroid of the map projection.
+func (prj Mercator) Spheroid() Spheroid {
+	if prj.par == nil {
+		panic("geomys.Mercator.Spheroid: uninitialized structure")
+	}
+	//
+	return prj.sph
+}
+
+// Params -- returns the parameters of the map projection.
+func (prj Mercator) Params() map[string]float64 {
+	if prj.par == nil {
+		panic("geomys.Mercator.Params: uninitialized structure")
+	}
+	//
+	par := make(map[string]float64)
+	for k, v := range prj.par {
+		par[k] = v
+	}
+	return par
+}
+
+// Project -- transforms a geographic point from
+// the spheroid into a location on the plane.
+// The poles are mapped to y=±∞.
+func (prj Mercator) Project(p Point) (xy [2]float64) {
+	xy, _, _ = prj.ProjectExt(p)
+	return
+}
+
+// ProjectExt -- transforms a geographic point from the spheroid into a location on the plane,
+// also returns the meridian convergence `γ` (degrees), that is the bearing of grid north
+// clockwise from true north, and the point scale `k`. The poles have k=+∞.
+func (prj Mercator) ProjectExt(p Point) (xy [2]float64, γ, k float64) {
+	if prj.par == nil {
+		panic("geomys.Mercator.ProjectExt: uninitialized structure")
+	}
+	//
+	lat, lon, _ := p.Geo()
+	λ := angNormalize(lon - prj.par["lon0"])
+	ψ := prj.sph.IsometricLat(lat)
+	xy[0] = prj.ak0*λ*(math.Pi/180) + prj.par["x0"]
+	xy[1] = prj.ak0*ψ*(math.Pi/180) + prj.par["y0"]
+	// k = a⋅k0/(N(φ)⋅cos(φ))
+	_, cosφ := mym.SinCosD(lat)
+	k = prj.ak0 / (prj.sph.PrimeVerticalRadius(lat) * cosφ)
+	return
+}
+
+// Unproject -- transforms a location on the plane into
+// a geographic point on the spheroid. Returns an error wrapping
+// ErrOutOfDomain when `xy` is outside the image of the spheroid.
+func (prj Mercator) Unproject(xy [2]float64) (Point, error) {
+	if prj.par == nil {
+		panic("geomys.Mercator.Unproject: uninitialized structure")
+	}
+	//
+	λ := (xy[0] - prj.par["x0"]) / prj.ak0 * (180 / math.Pi)
+	ψ := (xy[1] - prj.par["y0"]) / prj.ak0 * (180 / math.Pi)
+	if !(math.Abs(λ) <= 180) {
+		// allow for the round-off at the antimeridian
+		if !(math.Abs(λ) <= 180*(1+4*mym.Epsilon)) {
+			return Point{}, fmt.Errorf("geomys.Mercator.Unproject: %w: `xy`", ErrOutOfDomain)
+		}
+		λ = math.Copysign(180, λ)
+	}
+	if math.IsNaN(ψ) {
+		return Point{}, fmt.Errorf("geomys.Mercator.Unproject: %w: `xy`", ErrOutOfDomain)
+	}
+	lat := prj.sph.GeoLatIsometric(ψ)
+	return Geo(lat, angNormalize(prj.par["lon0"]+λ), 0.0), nil
+}
+
+// WebMercator -- the spherical Mercator map projection used by web maps (EPSG:3857).
+// The geographic coordinates on WGS1984 are treated as spherical coordinates
+// on the sphere of radius equal to the semi-major axis of WGS1984.
+type WebMercator struct{}
+
+// NewWebMercator -- returns the Web Mercator map projection.
+func NewWebMercator() WebMercator {
+	return WebMercator{}
+}
+
+// Spheroid -- returns the spheroid of the map projection, that is WGS1984.
+func (prj WebMercator) Spheroid() Spheroid {
+	return WGS1984()
+}
+
+// Params -- returns the parameters of the map projection.
+func (prj WebMercator) Params() map[string]float64 {
+	return map[string]float64{"lat1": 0, "lon0": 0, "x0": 0, "y0": 0}
+}
+
+// Project -- transforms a geographic point from
+// the spheroid into a location on the plane.
+// The poles are mapped to y=±∞.
+func (prj WebMercator) Project(p Point) (xy [2]float64) {
+	a := prj.Spheroid().A()
+	lat, lon, _ := p.Geo()
+	sinφ, cosφ := mym.SinCosD(lat)
+	xy[0] = a * lon * (math.Pi / 180)
+	xy[1] = a * math.Asinh(sinφ/cosφ)
+	return
+}
+
+// Unproject -- transforms a location on the plane into
+// a geographic point on the spheroid. Returns an error wrapping
+// ErrOutOfDomain when `xy` is outside the image of the spheroid.
+func (prj WebMercator) Unproject(xy [2]float64) (Point, error) {
+	a := prj.Spheroid().A()
+	lon := xy[0] / a * (180 / math.Pi)
+	if !(math.Abs(lon) <= 180) {
+		// allow for the round-off at the antimeridian
+		if !(math.Abs(lon) <= 180*(1+4*mym.Epsilon)) {
+			return Point{}, fmt.Errorf("geomys.WebMercator.Unproject: %w: `xy`", ErrOutOfDomain)
+		}
+		lon = math.Copysign(180, lon)
+	}
+	if math.IsNaN(xy[1]) {
+		return Point{}, fmt.Errorf("geomys.WebMercator.Unproject: %w: `xy`", ErrOutOfDomain)
+	}
+	lat := math.Atan(math.Sinh(xy[1]/a)) * (180 / math.Pi)
+	return Geo(lat, lon, 0.0), nil
+}
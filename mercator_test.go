@@ -0,0 +1,49 @@
+package geomys
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMercatorKnown(t *testing.T) {
+	// IOGP Guidance Note 7-2, Pulkovo 1942 / Mercator (variant B)
+	prj := NewMercator(NewSpheroid(6378245, 1/298.3), 42, 51, 0, 0)
+	xy := prj.Project(Geo(53, 53, 0))
+	if math.Abs(xy[0]-165704.29) > 0.01 || math.Abs(xy[1]-5171848.07) > 0.01 {
+		t.Errorf("Mercator.Project: got %v", xy)
+	}
+	web := NewWebMercator()
+	xy = web.Project(Geo(TileMaxLat, 180, 0))
+	if math.Abs(xy[0]-20037508.342789244) > 1e-6 || math.Abs(xy[1]-20037508.342789244) > 1e-6 {
+		t.Errorf("WebMercator.Project: got %v", xy)
+	}
+}
+
+func TestMercatorRoundTrip(t *testing.T) {
+	prjs := []InvertibleProjection{
+		NewMercator(WGS1984(), 0, 0, 0, 0),
+		NewMercator(SRMmax(), -35, 150, 5e5, 1e7),
+		NewWebMercator(),
+	}
+	for _, prj := range prjs {
+		for lat := -89.0; lat <= 89; lat += 2 {
+			for lon := -180.0; lon <= 180; lon += 15 {
+				p, err := prj.Unproject(prj.Project(Geo(lat, lon, 0)))
+				if err != nil {
+					t.Fatalf("%v (%v,%v): %v", prj.Params(), lat, lon, err)
+				}
+				lat2, lon2, _ := p.Geo()
+				dlon, _ := angDiff(lon, lon2)
+				if math.Abs(lat2-lat) > 1e-11 || math.Abs(dlon) > 1e-11 {
+					t.Errorf("%v (%v,%v): got (%v,%v)", prj.Params(), lat, lon, lat2, lon2)
+				}
+			}
+		}
+		for _, xy := range [][2]float64{{1e8, 0}, {0, math.NaN()}} {
+			if _, err := prj.Unproject(xy); !errors.Is(err, ErrOutOfDomain) {
+				t.Errorf("%v Unproject(%v): got %v", prj.Params(), xy, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package geomys
+
+import (
+	"github.com/reconditematter/mym"
+	"math"
+)
+
+// RectifyingRadius -- returns the rectifying radius (A) of `s`, that is the radius of a sphere
+// having equal meridian length to that of `s`:
+//
+//	A = a/(1+n)⋅(1 + n²/4 + n⁴/64 + n⁶/256 + ...),
+//
+// where n is the third flattening.
+func (s Spheroid) RectifyingRadius() float64 {
+	return s.A() * merA0f(s.Fpp())
+}
+
+// QuarterMeridian -- returns the distance (meters) from the equator to a pole along a meridian of `s`.
+func (s Spheroid) QuarterMeridian() float64 {
+	return s.RectifyingRadius() * (math.Pi / 2)
+}
+
+// MeridianDistance -- returns the distance (meters) along a meridian of `s` from the equator
+// to the geographic latitude `lat` (degrees), the distance is negative when lat<0:
+//
+//	m = A⋅μ,
+//
+// where A is the rectifying radius and μ is the rectifying latitude.
+// This function causes a runtime panic when lat∉[-90,90].
+func (s Spheroid) MeridianDistance(lat float64) float64 {
+	if !(-90 <= lat && lat <= 90) {
+		panic("geomys.Spheroid.MeridianDistance: domain error: `lat`")
+	}
+	μ := auxSeries(lat, merCf(s.Fpp()))
+	return s.RectifyingRadius() * μ * (math.Pi / 180)
+}
+
+// MeridianLat -- returns the geographic latitude (degrees) at the distance `m` (meters)
+// from the equator along a meridian of `s`, this is the inverse of MeridianDistance.
+// This function causes a runtime panic when |m| exceeds QuarterMeridian.
+func (s Spheroid) MeridianLat(m float64) float64 {
+	μ := m / s.RectifyingRadius() * (180 / math.Pi)
+	if !(-90 <= μ && μ <= 90) {
+		if math.Abs(μ) <= 90*(1+4*mym.Epsilon) {
+			μ = math.Copysign(90, μ)
+		} else {
+			panic("geomys.Spheroid.MeridianLat: domain error: `m`")
+		}
+	}
+	return s.GeoLat(RectifyingLat, μ)
+}
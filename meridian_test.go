@@ -0,0 +1,37 @@
+package geomys
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMeridianDistance(t *testing.T) {
+	spheroids := map[string]Spheroid{
+		"Clarke1866":        Clarke1866(),
+		"International1924": International1924(),
+		"WGS1972":           WGS1972(),
+		"GRS1967":           GRS1967(),
+		"GRS1980":           GRS1980(),
+		"WGS1984":           WGS1984(),
+		"IERS2003":          IERS2003(),
+		"SRMmax":            SRMmax(),
+	}
+	for name, sph := range spheroids {
+		if qm := meridianQuad(sph, 90); math.Abs(sph.QuarterMeridian()-qm) > 1e-8 {
+			t.Errorf("%s QuarterMeridian: got %v, want %v", name, sph.QuarterMeridian(), qm)
+		}
+		for φ := -90.0; φ <= 90; φ += 1.5 {
+			m := sph.MeridianDistance(φ)
+			if want := meridianQuad(sph, φ); math.Abs(m-want) > 1e-8 {
+				t.Errorf("%s MeridianDistance(%v): got %v, want %v", name, φ, m, want)
+			}
+			if φ2 := sph.MeridianLat(m); math.Abs(φ2-φ) > 1e-13 {
+				t.Errorf("%s MeridianLat(%v): got %v, want %v", name, m, φ2, φ)
+			}
+		}
+	}
+	// the quarter meridian of WGS1984 by GeographicLib
+	if qm := WGS1984().QuarterMeridian(); math.Abs(qm-10001965.7293127228) > 1e-8 {
+		t.Errorf("QuarterMeridian: got %v", qm)
+	}
+}
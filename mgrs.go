@@ -0,0 +1,292 @@
+package geomys
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	MGRSNew = iota // the new (AA) 100 km square lettering scheme
+	MGRSOld        // the old (AL) 100 km square lettering scheme
+)
+
+// The errors wrapped by MGRSError.
+var (
+	ErrMGRSSyntax    = errors.New("invalid syntax")
+	ErrMGRSZone      = errors.New("invalid zone")
+	ErrMGRSBand      = errors.New("invalid latitude band")
+	ErrMGRSSquare    = errors.New("invalid 100 km square")
+	ErrMGRSPrecision = errors.New("invalid precision")
+)
+
+// MGRSError -- the error returned when an MGRS/USNG reference cannot be decoded.
+type MGRSError struct {
+	Ref string // the reference being decoded
+	Err error  // one of ErrMGRSSyntax,ErrMGRSZone,ErrMGRSBand,ErrMGRSSquare,ErrMGRSPrecision
+}
+
+func (e *MGRSError) Error() string {
+	return fmt.Sprintf("geomys.MGRSGeo: %v: %q", e.Err, e.Ref)
+}
+
+func (e *MGRSError) Unwrap() error {
+	return e.Err
+}
+
+const (
+	mgrsBands   = "CDEFGHJKLMNPQRSTUVWX"
+	mgrsRows    = "ABCDEFGHJKLMNPQRSTUV"
+	upsBands    = "ABYZ"
+	mgrsUPSWest = 20 // the UPS easting (100 km) separating the west and east bands
+)
+
+var (
+	mgrsCols = [3]string{"ABCDEFGH", "JKLMNPQR", "STUVWXYZ"}
+	upsCols  = [4]string{"JKLPQRSTUXYZ", "ABCFGHJKLPQR", "RSTUXYZ", "ABCFGHJ"}
+	upsRows  = [2]string{"ABCDEFGHJKLMNPQRSTUVWXYZ", "ABCDEFGHJKLMNP"}
+	upsMin   = [2]int{8, 13} // the minimal UPS easting/northing (100 km), south and north
+)
+
+// MGRS -- computes the Military Grid Reference System (MGRS) reference of `p`
+// on the spheroid `sph` with `prec` digits per coordinate, that is with the resolution
+// of 100 km (prec=0), 10 km, 1 km, 100 m, 10 m, or 1 m (prec=5). The 100 km squares
+// are lettered according to `scheme` (MGRSNew,MGRSOld).
+//
+// See: https://en.wikipedia.org/wiki/Military_Grid_Reference_System
+//
+// When prec<0, it is set to 0; when prec>5, it is set to 5.
+// This function causes a runtime panic when `scheme` is neither MGRSNew nor MGRSOld.
+func MGRS(sph Spheroid, p Point, prec int, scheme int) string {
+	zone, square, x, y := mgrsEncode(sph, p, prec, scheme)
+	return zone + square + x + y
+}
+
+// USNG -- computes the United States National Grid (USNG) reference of `p`
+// on the spheroid `sph` with `prec` digits per coordinate. The reference is
+// the MGRS reference in the new lettering scheme with the components separated
+// by spaces and the zone without a leading zero, e.g. "4Q FJ 12345 67890".
+//
+// When prec<0, it is set to 0; when prec>5, it is set to 5.
+func USNG(sph Spheroid, p Point, prec int) string {
+	zone, square, x, y := mgrsEncode(sph, p, prec, MGRSNew)
+	zone = strings.TrimPrefix(zone, "0")
+	if x == "" {
+		return zone + " " + square
+	}
+	return zone + " " + square + " " + x + " " + y
+}
+
+// MGRSGeo -- decodes the MGRS reference `ref` on the spheroid `sph` into a geographic point `p`,
+// the center of the referenced grid square, and the number of digits per coordinate `prec`.
+// The 100 km squares are assumed to be lettered according to `scheme` (MGRSNew,MGRSOld).
+// The reference is case insensitive and may contain spaces after the zone with the latitude band,
+// after the 100 km square, and between the easting and the northing digits.
+// Returns an error of type *MGRSError when `ref` is malformed or inconsistent.
+// This function causes a runtime panic when `scheme` is neither MGRSNew nor MGRSOld.
+func MGRSGeo(sph Spheroid, ref string, scheme int) (p Point, prec int, err error) {
+	if !(scheme == MGRSNew || scheme == MGRSOld) {
+		panic("geomys.MGRSGeo: domain error: `scheme`")
+	}
+	//
+	fail := func(e error) (Point, int, error) {
+		return Point{}, 0, &MGRSError{Ref: ref, Err: e}
+	}
+	// the components may be separated by spaces
+	fields := strings.Fields(strings.ToUpper(ref))
+	s := strings.Join(fields, "")
+	if len(s) == 0 {
+		return fail(ErrMGRSSyntax)
+	}
+	// zone
+	k := 0
+	for k < len(s) && k < 3 && '0' <= s[k] && s[k] <= '9' {
+		k++
+	}
+	zone := 0
+	switch k {
+	case 0:
+	case 1, 2:
+		for _, c := range s[:k] {
+			zone = 10*zone + int(c-'0')
+		}
+		if !(1 <= zone && zone <= 60) {
+			return fail(ErrMGRSZone)
+		}
+	default:
+		return fail(ErrMGRSZone)
+	}
+	// latitude band and 100 km square
+	if len(s) < k+3 {
+		return fail(ErrMGRSSyntax)
+	}
+	band, col, row := s[k], s[k+1], s[k+2]
+	digits := s[k+3:]
+	for i := 0; i < len(digits); i++ {
+		if !('0' <= digits[i] && digits[i] <= '9') {
+			return fail(ErrMGRSSyntax)
+		}
+	}
+	// the spaces may follow the zone with the band and the 100 km square,
+	// and may separate the digits into two groups of equal length
+	n := 0
+	for _, f := range fields[:len(fields)-1] {
+		n += len(f)
+		switch {
+		case n == k+1 || n == k+3:
+		case n > k+3:
+			if 2*(n-k-3) != len(digits) {
+				return fail(ErrMGRSPrecision)
+			}
+		default:
+			return fail(ErrMGRSSyntax)
+		}
+	}
+	if len(digits)%2 != 0 || len(digits) > 10 {
+		return fail(ErrMGRSPrecision)
+	}
+	prec = len(digits) / 2
+	unit := math.Pow10(5 - prec)
+	var dx, dy float64
+	for i := 0; i < prec; i++ {
+		dx = 10*dx + float64(digits[i]-'0')
+		dy = 10*dy + float64(digits[prec+i]-'0')
+	}
+	// the center of the grid square
+	dx = (dx + 0.5) * unit
+	dy = (dy + 0.5) * unit
+	//
+	var (
+		c              UTMCoord
+		latmin, latmax float64
+	)
+	if zone == 0 {
+		ib := strings.IndexByte(upsBands, band)
+		if ib < 0 {
+			return fail(ErrMGRSBand)
+		}
+		north := ib >= 2
+		hemi := 0
+		if north {
+			hemi = 1
+		}
+		ic := strings.IndexByte(upsCols[ib], col)
+		ir := strings.IndexByte(upsRows[hemi], row)
+		if ic < 0 || ir < 0 {
+			return fail(ErrMGRSSquare)
+		}
+		if ib%2 == 0 {
+			ic += upsMin[hemi]
+		} else {
+			ic += mgrsUPSWest
+		}
+		ir += upsMin[hemi]
+		c = UTMCoord{Zone: 0, North: north, Easting: float64(ic)*1e5 + dx, Northing: float64(ir)*1e5 + dy}
+	} else {
+		ib := strings.IndexByte(mgrsBands, band)
+		if ib < 0 {
+			return fail(ErrMGRSBand)
+		}
+		north := ib >= 10
+		ic := strings.IndexByte(mgrsCols[(zone-1)%3], col)
+		ir := strings.IndexByte(mgrsRows, row)
+		if ic < 0 || ir < 0 {
+			return fail(ErrMGRSSquare)
+		}
+		ir = (ir - mgrsRowOffset(zone, scheme) + 40) % 20
+		// the row letters repeat every 2000 km, choose the northing
+		// nearest to the center of the latitude band
+		latmin, latmax = mgrsBandLats(ib)
+		lon0 := float64(6*zone - 183)
+		yb := NewUTM(sph, zone, north).Project(Geo((latmin+latmax)/2, lon0, 0))[1] / 1e5
+		ir += 20 * int(math.Round((yb-float64(ir)-0.5)/20))
+		c = UTMCoord{Zone: zone, North: north, Easting: float64(ic+1)*1e5 + dx, Northing: float64(ir)*1e5 + dy}
+	}
+	p, err = FromUTM(sph, c)
+	if err != nil {
+		return fail(ErrMGRSSquare)
+	}
+	// allow for the grid squares straddling the band boundaries
+	if lat, _, _ := p.Geo(); zone != 0 && !(latmin-1 <= lat && lat <= latmax+1) {
+		return fail(ErrMGRSBand)
+	}
+	return p, prec, nil
+}
+
+// USNGGeo -- decodes the USNG reference `ref` on the spheroid `sph` as MGRSGeo
+// does with the new lettering scheme.
+func USNGGeo(sph Spheroid, ref string) (p Point, prec int, err error) {
+	return MGRSGeo(sph, ref, MGRSNew)
+}
+
+// mgrsEncode -- computes the components of the MGRS reference of `p`:
+// the zone with the latitude band, the 100 km square, the easting and northing digits.
+func mgrsEncode(sph Spheroid, p Point, prec int, scheme int) (zone, square, x, y string) {
+	if !(scheme == MGRSNew || scheme == MGRSOld) {
+		panic("geomys.MGRS: domain error: `scheme`")
+	}
+	if prec < 0 {
+		prec = 0
+	}
+	if prec > 5 {
+		prec = 5
+	}
+	//
+	c := ToUTM(sph, p)
+	ix := int(math.Floor(c.Easting / 1e5))
+	iy := int(math.Floor(c.Northing / 1e5))
+	if c.Zone == 0 {
+		hemi := 0
+		if c.North {
+			hemi = 1
+		}
+		ib := 2 * hemi
+		ic := ix - upsMin[hemi]
+		if ix >= mgrsUPSWest {
+			ib++
+			ic = ix - mgrsUPSWest
+		}
+		zone = upsBands[ib : ib+1]
+		square = string([]byte{upsCols[ib][ic], upsRows[hemi][iy-upsMin[hemi]]})
+	} else {
+		lat, _, _ := p.Geo()
+		ib := int(math.Floor((lat + 80) / 8))
+		if ib > 19 {
+			ib = 19
+		}
+		ir := (iy + mgrsRowOffset(c.Zone, scheme)) % 20
+		zone = fmt.Sprintf("%02d%c", c.Zone, mgrsBands[ib])
+		square = string([]byte{mgrsCols[(c.Zone-1)%3][ix-1], mgrsRows[ir]})
+	}
+	if prec > 0 {
+		unit := int64(math.Pow10(5 - prec))
+		ex := int64(math.Floor(c.Easting)) % 100000 / unit
+		ny := int64(math.Floor(c.Northing)) % 100000 / unit
+		x = fmt.Sprintf("%0*d", prec, ex)
+		y = fmt.Sprintf("%0*d", prec, ny)
+	}
+	return
+}
+
+// mgrsRowOffset -- returns the offset of the row letters in the zone `zone`.
+func mgrsRowOffset(zone int, scheme int) int {
+	offset := 0
+	if zone%2 == 0 {
+		offset = 5
+	}
+	if scheme == MGRSOld {
+		offset += 10
+	}
+	return offset
+}
+
+// mgrsBandLats -- returns the latitude limits of the UTM latitude band `ib`.
+func mgrsBandLats(ib int) (latmin, latmax float64) {
+	latmin = float64(8*ib - 80)
+	latmax = latmin + 8
+	if ib == 19 {
+		latmax = 84
+	}
+	return
+}
@@ -0,0 +1,99 @@
+package geomys
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMGRSKnown(t *testing.T) {
+	sph := WGS1984()
+	cases := []struct {
+		p      Point
+		prec   int
+		scheme int
+		ref    string
+	}{
+		{Geo(33.3, 44.4, 0), 5, MGRSNew, "38SMB4414084706"},
+		{Geo(33.3, 44.4, 0), 5, MGRSOld, "38SMM4414084706"},
+		{Geo(33.3, 44.4, 0), 2, MGRSNew, "38SMB4484"},
+		{Geo(33.3, 44.4, 0), 0, MGRSNew, "38SMB"},
+		{Geo(90, 0, 0), 5, MGRSNew, "ZAH0000000000"},
+		{Geo(-90, 0, 0), 5, MGRSNew, "BAN0000000000"},
+	}
+	for _, c := range cases {
+		if ref := MGRS(sph, c.p, c.prec, c.scheme); ref != c.ref {
+			t.Errorf("MGRS(%v,%v): got %v, want %v", c.p, c.prec, ref, c.ref)
+		}
+	}
+	if ref := USNG(sph, Geo(19.5, -155.5, 0), 3); ref != "5Q KB 376 580" {
+		t.Errorf("USNG: got %v", ref)
+	}
+}
+
+func TestMGRSRoundTrip(t *testing.T) {
+	sph := WGS1984()
+	g := NewGeodesic(sph)
+	for _, scheme := range []int{MGRSNew, MGRSOld} {
+		for lat := -90.0; lat <= 90; lat += 1.5 {
+			for lon := -180.0; lon < 180; lon += 6.5 {
+				p := Geo(lat, lon, 0)
+				ref := MGRS(sph, p, 5, scheme)
+				q, prec, err := MGRSGeo(sph, ref, scheme)
+				if err != nil {
+					t.Fatalf("(%v,%v) %v: %v", lat, lon, ref, err)
+				}
+				if d, _, _ := g.Inverse(p, q); prec != 5 || d > 1 {
+					t.Errorf("(%v,%v) %v: d=%v", lat, lon, ref, d)
+				}
+			}
+		}
+	}
+}
+
+func TestMGRSErrors(t *testing.T) {
+	sph := WGS1984()
+	cases := []struct {
+		ref string
+		err error
+	}{
+		{"", ErrMGRSSyntax},
+		{"38SMB44140-84706", ErrMGRSSyntax},
+		{"38S", ErrMGRSSyntax},
+		{"61SMB", ErrMGRSZone},
+		{"00SMB", ErrMGRSZone},
+		{"138SMB", ErrMGRSZone},
+		{"38IMB", ErrMGRSBand},
+		{"38YMB", ErrMGRSBand},
+		{"CAH", ErrMGRSBand},
+		{"38SAB", ErrMGRSSquare},
+		{"38SMW", ErrMGRSSquare},
+		{"ZMH", ErrMGRSSquare},
+		{"38SMB441", ErrMGRSPrecision},
+		{"38SMB441408470612", ErrMGRSPrecision},
+		{"38SMT", ErrMGRSBand},
+		{"33UXP 010 1", ErrMGRSPrecision},
+		{"38S MB 4418 47", ErrMGRSPrecision},
+		{"38S MB 441 847 1", ErrMGRSPrecision},
+		{"3 3UXP0101", ErrMGRSSyntax},
+		{"33 UXP0101", ErrMGRSSyntax},
+		{"33UX P0101", ErrMGRSSyntax},
+		{"ZA A", ErrMGRSSyntax},
+	}
+	for _, c := range cases {
+		_, _, err := MGRSGeo(sph, c.ref, MGRSNew)
+		var merr *MGRSError
+		if !errors.As(err, &merr) || !errors.Is(err, c.err) {
+			t.Errorf("MGRSGeo(%q): got %v, want %v", c.ref, err, c.err)
+		}
+	}
+	p, _, _ := MGRSGeo(sph, "38SMB441847", MGRSNew)
+	for _, ref := range []string{"38s mb 441 847", " 38S MB441847 ", "38SMB 441847", "38S MB441 847"} {
+		q, prec, err := USNGGeo(sph, ref)
+		if err != nil || prec != 3 || q != p {
+			t.Errorf("USNGGeo(%q): got %v,%v,%v", ref, q, prec, err)
+		}
+	}
+	if _, prec, err := USNGGeo(sph, "Z AH"); err != nil || prec != 0 {
+		t.Errorf("USNGGeo: got %v,%v", prec, err)
+	}
+}
@@ -0,0 +1,205 @@
+package geomys
+
+import (
+	"math"
+)
+
+// PolygonArea -- an accumulator of the perimeter and the area of a geodesic polygon.
+// The vertices are added one at a time with AddPoint or AddEdge, the polygon
+// is closed implicitly by the geodesic from the last vertex to the first one.
+// Polygons that enclose a pole or cross the antimeridian are handled correctly.
+type PolygonArea struct {
+	g            Geodesic
+	num          int
+	lat0, lon0   float64
+	lat1, lon1   float64
+	perim, area  accum
+	crossings    int
+	area0, area2 float64
+}
+
+// NewPolygonArea -- returns an empty polygon on the spheroid `sph`.
+func NewPolygonArea(sph Spheroid) PolygonArea {
+	area0 := 4 * math.Pi * sph.Rs() * sph.Rs()
+	return PolygonArea{g: NewGeodesic(sph), area0: area0, area2: area0 / 2}
+}
+
+// Spheroid -- returns the spheroid of `pa`.
+func (pa PolygonArea) Spheroid() Spheroid {
+	return pa.g.sph
+}
+
+// Clear -- removes all vertices from `pa`.
+func (pa *PolygonArea) Clear() {
+	*pa = PolygonArea{g: pa.g, area0: pa.area0, area2: pa.area2}
+}
+
+// Count -- returns the number of vertices of `pa`.
+func (pa PolygonArea) Count() int {
+	return pa.num
+}
+
+// AddPoint -- adds the vertex `p` to `pa`.
+func (pa *PolygonArea) AddPoint(p Point) {
+	lat, lon, _ := p.Geo()
+	if pa.num == 0 {
+		pa.lat0, pa.lon0 = lat, lon
+	} else {
+		inv := pa.g.geninverse(pa.lat1, pa.lon1, lat, lon, OutArea)
+		pa.perim.add(inv.s12)
+		pa.area.add(inv.S12)
+		pa.crossings += transit(pa.lon1, lon)
+	}
+	pa.lat1, pa.lon1 = lat, lon
+	pa.num++
+}
+
+// AddEdge -- adds the vertex at the distance `s12` (meters) from the last vertex
+// of `pa` in the direction of the azimuth `α12` (degrees).
+// This function causes a runtime panic when `pa` is empty.
+func (pa *PolygonArea) AddEdge(α12, s12 float64) {
+	if pa.num == 0 {
+		panic("geomys.PolygonArea.AddEdge: domain error: no vertices")
+	}
+	pos := pa.edge(α12, s12)
+	pa.perim.add(s12)
+	pa.area.add(pos.S12)
+	lon := pa.lon1 + pos.lon12
+	pa.crossings += transitdirect(pa.lon1, lon)
+	pa.lat1, pa.lon1 = pos.p2.lat, lon
+	pa.num++
+}
+
+// Compute -- returns the number of vertices `n`, the perimeter `perimeter` (meters),
+// and the signed area `area` (square meters) of `pa`. The area is positive when
+// the vertices are traversed counter-clockwise and negative otherwise; its absolute
+// value does not exceed half of the area of the spheroid.
+func (pa PolygonArea) Compute() (n int, perimeter, area float64) {
+	if pa.num < 2 {
+		return pa.num, 0, 0
+	}
+	inv := pa.g.geninverse(pa.lat1, pa.lon1, pa.lat0, pa.lon0, OutArea)
+	perim, sum := pa.perim, pa.area
+	perim.add(inv.s12)
+	sum.add(inv.S12)
+	crossings := pa.crossings + transit(pa.lon1, pa.lon0)
+	return pa.num, perim.s, pa.reduce(sum.s, crossings)
+}
+
+// TestPoint -- returns the results of Compute as if the vertex `p` was added to `pa`.
+// The vertices of `pa` are not changed.
+func (pa PolygonArea) TestPoint(p Point) (n int, perimeter, area float64) {
+	if pa.num == 0 {
+		return 1, 0, 0
+	}
+	lat, lon, _ := p.Geo()
+	perim, sum := pa.perim, pa.area
+	crossings := pa.crossings
+	inv := pa.g.geninverse(pa.lat1, pa.lon1, lat, lon, OutArea)
+	perim.add(inv.s12)
+	sum.add(inv.S12)
+	crossings += transit(pa.lon1, lon)
+	inv = pa.g.geninverse(lat, lon, pa.lat0, pa.lon0, OutArea)
+	perim.add(inv.s12)
+	sum.add(inv.S12)
+	crossings += transit(lon, pa.lon0)
+	return pa.num + 1, perim.s, pa.reduce(sum.s, crossings)
+}
+
+// TestEdge -- returns the results of Compute as if the edge with the azimuth `α12` (degrees)
+// and the length `s12` (meters) was added to `pa`. The vertices of `pa` are not changed.
+// This function causes a runtime panic when `pa` is empty.
+func (pa PolygonArea) TestEdge(α12, s12 float64) (n int, perimeter, area float64) {
+	if pa.num == 0 {
+		panic("geomys.PolygonArea.TestEdge: domain error: no vertices")
+	}
+	perim, sum := pa.perim, pa.area
+	crossings := pa.crossings
+	pos := pa.edge(α12, s12)
+	perim.add(s12)
+	sum.add(pos.S12)
+	lon := pa.lon1 + pos.lon12
+	crossings += transitdirect(pa.lon1, lon)
+	inv := pa.g.geninverse(pos.p2.lat, lon, pa.lat0, pa.lon0, OutArea)
+	perim.add(inv.s12)
+	sum.add(inv.S12)
+	crossings += transit(lon, pa.lon0)
+	return pa.num + 1, perim.s, pa.reduce(sum.s, crossings)
+}
+
+// edge -- solves the direct problem from the last vertex of `pa`.
+func (pa PolygonArea) edge(α12, s12 float64) geodpos {
+	l := NewGeodesicLine(pa.g, Geo(pa.lat1, angNormalize(pa.lon1), 0.0), α12)
+	return l.genposition(false, s12)
+}
+
+// reduce -- reduces the accumulated area `area` of the clockwise traversal
+// with `crossings` crossings of the prime meridian to (-area0/2,area0/2]
+// with the counter-clockwise sense.
+func (pa PolygonArea) reduce(area float64, crossings int) float64 {
+	area = math.Remainder(area, pa.area0)
+	if crossings&1 != 0 {
+		// the polygon encloses a pole
+		if area < 0 {
+			area += pa.area2
+		} else {
+			area -= pa.area2
+		}
+	}
+	area = -area
+	if area > pa.area2 {
+		area -= pa.area0
+	} else if area <= -pa.area2 {
+		area += pa.area0
+	}
+	return 0 + area
+}
+
+// transit -- returns +1 or -1 when the shortest path from `lon1` to `lon2` (degrees)
+// crosses the prime meridian eastward or westward, otherwise returns 0.
+// The longitude ±0 is considered positive.
+func transit(lon1, lon2 float64) int {
+	lon12, _ := angDiff(lon1, lon2)
+	lon1 = angNormalize(lon1)
+	lon2 = angNormalize(lon2)
+	switch {
+	case lon12 > 0 && ((lon1 < 0 && lon2 >= 0) || (lon1 > 0 && lon2 == 0)):
+		return 1
+	case lon12 < 0 && lon1 >= 0 && lon2 < 0:
+		return -1
+	}
+	return 0
+}
+
+// transitdirect -- returns the number of crossings of the prime meridian
+// between the unrolled longitudes `lon1` and `lon2` (degrees) modulo 2,
+// that is the parity of ⌊lon2/360⌋-⌊lon1/360⌋.
+func transitdirect(lon1, lon2 float64) int {
+	lon1 = math.Remainder(lon1, 720)
+	lon2 = math.Remainder(lon2, 720)
+	n := 0
+	if !(0 <= lon2 && lon2 < 360) {
+		n++
+	}
+	if !(0 <= lon1 && lon1 < 360) {
+		n--
+	}
+	return n
+}
+
+// accum -- an accumulator of a sum in double-double precision.
+type accum struct {
+	s, t float64
+}
+
+// add -- adds `y` to the sum `a`.
+func (a *accum) add(y float64) {
+	var u float64
+	y, u = sum2(y, a.t)
+	a.s, a.t = sum2(y, a.s)
+	if a.s == 0 {
+		a.s = u
+	} else {
+		a.t += u
+	}
+}
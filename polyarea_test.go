@@ -0,0 +1,138 @@
+package geomys
+
+import (
+	"math"
+	"testing"
+)
+
+// polyAreaTests -- the polygons on WGS1984 with the perimeters and the areas by GeographicLib (Planimeter).
+var polyAreaTests = []struct {
+	name          string
+	vertices      [][2]float64
+	perim, area   float64
+	tolp, tolarea float64
+}{
+	{"Antarctica", [][2]float64{
+		{-63.1, -58}, {-72.9, -74}, {-71.9, -102}, {-74.9, -102}, {-74.3, -131}, {-77.5, -163},
+		{-77.4, 163}, {-71.7, 172}, {-65.9, 140}, {-65.7, 113}, {-66.6, 88}, {-66.9, 59},
+		{-69.8, 25}, {-70.0, -4}, {-71.0, -14}, {-77.3, -33}, {-77.9, -46}, {-74.7, -61},
+	}, 16831067.89279071, 13662703680020.1, 1e-6, 1},
+	{"north cap", [][2]float64{{89, 0}, {89, 90}, {89, 180}, {89, -90}}, 631819.8745, 24952305678.0, 1e-4, 1},
+	{"south cap", [][2]float64{{-89, 0}, {-89, 90}, {-89, 180}, {-89, -90}}, 631819.8745, -24952305678.0, 1e-4, 1},
+	{"octant", [][2]float64{{90, 0}, {0, 0}, {0, 90}}, 30022685, 63758202715511.0, 1, 1},
+	{"pole triangle", [][2]float64{{89, 0.1}, {89, 90.1}, {89, -179.9}}, 539297, 12476152838.5, 1, 1},
+	{"twice around a pole", [][2]float64{{89, 0}, {89, 120}, {89, -120}, {89, 0}, {89, 120}, {89, -120}}, 1160741, 32415230256.0, 1, 1},
+	{"degenerate", [][2]float64{{9, -0.00000000000001}, {9, 180}, {9, 0}}, 36026861, 0, 1, 1},
+	{"degenerate", [][2]float64{{9, 0.00000000000001}, {9, 0}, {9, 180}}, 36026861, 0, 1, 1},
+}
+
+func TestPolygonAreaKnown(t *testing.T) {
+	for _, c := range polyAreaTests {
+		for _, reverse := range []bool{false, true} {
+			pa := NewPolygonArea(WGS1984())
+			for i := range c.vertices {
+				v := c.vertices[i]
+				if reverse {
+					v = c.vertices[len(c.vertices)-1-i]
+				}
+				pa.AddPoint(Geo(v[0], v[1], 0))
+			}
+			// the reversed traversal flips the sign of the area
+			want := c.area
+			if reverse {
+				want = -want
+			}
+			n, perim, area := pa.Compute()
+			if n != len(c.vertices) || math.Abs(perim-c.perim) > c.tolp || math.Abs(area-want) > c.tolarea {
+				t.Errorf("%s reverse=%v: got %v %v %v", c.name, reverse, n, perim, area)
+			}
+		}
+	}
+}
+
+func TestPolygonAreaAntimeridian(t *testing.T) {
+	// the same quadrangle across the antimeridian and across the prime meridian
+	var want float64
+	for i, lon := range []float64{180, 0, -90} {
+		pa := NewPolygonArea(WGS1984())
+		for _, v := range [][2]float64{{-16, -1}, {-16, 1}, {-18, 1}, {-18, -1}} {
+			pa.AddPoint(Geo(v[0], angNormalize(lon+v[1]), 0))
+		}
+		_, _, area := pa.Compute()
+		if i == 0 {
+			want = area
+		}
+		if area > 0 || math.Abs(area-want) > 1e-3 || math.Abs(area+4.7e10) > 0.1e10 {
+			t.Errorf("lon=%v: got %v, want %v", lon, area, want)
+		}
+	}
+}
+
+func TestPolygonAreaCircuits(t *testing.T) {
+	// GeographicLib Planimeter21: the circuits around the north pole through
+	// the vertices (45,60), (45,180), (45,-60) with the edges of the azimuth `azi`
+	// and the length `s` at the start
+	const (
+		azi = 39.2144607176828184218
+		s   = 8420705.40957178156285
+		r   = 39433884866571.4277 // the area of one circuit
+	)
+	pa := NewPolygonArea(WGS1984())
+	pa.AddPoint(Geo(45, 60, 0))
+	pa.AddPoint(Geo(45, 180, 0))
+	pa.AddPoint(Geo(45, -60, 0))
+	pa.AddPoint(Geo(45, 60, 0))
+	pa.AddPoint(Geo(45, 180, 0))
+	pa.AddPoint(Geo(45, -60, 0))
+	for i := 3; i <= 4; i++ {
+		pa.AddPoint(Geo(45, 60, 0))
+		pa.AddPoint(Geo(45, 180, 0))
+		// the previews leave the accumulator unchanged
+		before := pa
+		if _, _, area := pa.TestPoint(Geo(45, -60, 0)); math.Abs(area-float64(i)*r) > 0.5 {
+			t.Errorf("TestPoint %v: got %v", i, area)
+		}
+		if _, _, area := pa.TestEdge(azi, s); math.Abs(area-float64(i)*r) > 0.5 {
+			t.Errorf("TestEdge %v: got %v", i, area)
+		}
+		if pa != before {
+			t.Errorf("TestPoint,TestEdge %v: the accumulator is changed", i)
+		}
+		pa.AddPoint(Geo(45, -60, 0))
+		if _, _, area := pa.Compute(); math.Abs(area-float64(i)*r) > 0.5 {
+			t.Errorf("Compute %v: got %v", i, area)
+		}
+	}
+	// the same circuits by the edges
+	pa.Clear()
+	pa.AddPoint(Geo(45, 60, 0))
+	for i := 1; i <= 4; i++ {
+		for j := 0; j < 3; j++ {
+			pa.AddEdge(azi, s)
+		}
+		n, perim, area := pa.Compute()
+		if n != 3*i+1 || math.Abs(perim-float64(3*i)*s) > 1e-6 || math.Abs(area-float64(i)*r) > 0.5 {
+			t.Errorf("AddEdge %v: got %v %v %v", i, n, perim, area)
+		}
+	}
+}
+
+func TestPolygonAreaPreview(t *testing.T) {
+	pa := NewPolygonArea(WGS1984())
+	if n, perim, area := pa.TestPoint(Geo(10, 10, 0)); n != 1 || perim != 0 || area != 0 {
+		t.Errorf("TestPoint empty: got %v %v %v", n, perim, area)
+	}
+	for _, v := range polyAreaTests[0].vertices[:17] {
+		pa.AddPoint(Geo(v[0], v[1], 0))
+	}
+	before := pa
+	n, perim, area := pa.TestPoint(Geo(-74.7, -61, 0))
+	if pa != before || n != 18 || math.Abs(perim-16831067.89279071) > 1e-6 || math.Abs(area-13662703680020.1) > 1 {
+		t.Errorf("TestPoint: got %v %v %v", n, perim, area)
+	}
+	s12, α1, _ := NewGeodesic(WGS1984()).Inverse(Geo(-77.9, -46, 0), Geo(-74.7, -61, 0))
+	n, perim, area = pa.TestEdge(α1, s12)
+	if pa != before || n != 18 || math.Abs(perim-16831067.89279071) > 1e-6 || math.Abs(area-13662703680020.1) > 1 {
+		t.Errorf("TestEdge: got %v %v %v", n, perim, area)
+	}
+}
@@ -81,3 +81,298 @@ func ellSinSeries(sin, cos float64, c []float64) float64 {
 	}
 	return 2 * sin * cos * y0
 }
+
+func ellA2m1f(eps float64) float64 {
+	const n = 8
+	const m = n / 2
+	coeff := [...]float64{-375, -704, -1792, -12288, 0, 16384}
+	t := polyval(m, coeff[:], 0, eps*eps) / coeff[m+1]
+	return (t - eps) / (1 + eps)
+}
+
+func ellC2f(eps float64) (C [9]float64) {
+	const n = 8
+	coeff := [...]float64{41, 64, 128, 1024, 2048, 47, 70, 128, 768, 4096, 69, 120, 640, 6144, 133, 224, 1120, 16384, 105, 504, 10240, 33, 154, 4096, 429, 14336, 6435, 262144}
+	eps2, d := eps*eps, eps
+	oo := 0
+	for L := 1; L <= n; L++ {
+		m := (n - L) / 2
+		C[L] = d * polyval(m, coeff[:], oo, eps2) / coeff[oo+m+1]
+		oo += m + 2
+		d *= eps
+	}
+	return
+}
+
+func ellA3x(n float64) (A3x [8]float64) {
+	const nA3 = 8
+	coeff := [...]float64{-25, 2048, -15, -20, 1024, -5, -10, -6, 256, -5, -20, -4, -6, 128, 5, -1, -3, -1, 16, 3, -1, -2, 8, 1, -1, 2, 1, 1}
+	oo, k := 0, 0
+	for j := nA3 - 1; j >= 0; j-- {
+		m := nA3 - j - 1
+		if j < m {
+			m = j
+		}
+		A3x[k] = polyval(m, coeff[:], oo, n) / coeff[oo+m+1]
+		k++
+		oo += m + 2
+	}
+	return
+}
+
+func ellC3x(n float64) (C3x [28]float64) {
+	const nC3 = 8
+	coeff := [...]float64{243, 16384, 10, 21, 1024, 3, 11, 12, 512, -2, 2, 2, 5, 128, -5, -1, 3, 3, 64, -1, 0, 1, 8, -1, 1, 4, 187, 16384, 69, 108, 8192, -2, 1, 5, 256, -6, -9, 2, 6, 256, 2, -3, -2, 3, 64, 1, -3, 2, 32, 139, 16384, -1, 12, 1024, -77, -8, 42, 3072, 10, -6, -10, 9, 384, -1, 5, -9, 5, 192, 127, 16384, -43, 72, 8192, -7, -40, 28, 2048, -7, 20, -28, 14, 1024, 99, 16384, -15, 9, 1024, 75, -90, 42, 5120, 99, 16384, -99, 44, 8192, 429, 114688}
+	oo, k := 0, 0
+	for L := 1; L < nC3; L++ {
+		for j := nC3 - 1; j >= L; j-- {
+			m := nC3 - j - 1
+			if j < m {
+				m = j
+			}
+			C3x[k] = polyval(m, coeff[:], oo, n) / coeff[oo+m+1]
+			k++
+			oo += m + 2
+		}
+	}
+	return
+}
+
+func ellA3f(A3x *[8]float64, eps float64) float64 {
+	return polyval(len(A3x)-1, A3x[:], 0, eps)
+}
+
+func ellC3f(C3x *[28]float64, eps float64) (C [8]float64) {
+	const nC3 = 8
+	d := 1.0
+	oo := 0
+	for L := 1; L < nC3; L++ {
+		m := nC3 - L - 1
+		d *= eps
+		C[L] = d * polyval(m, C3x[:], oo, eps)
+		oo += m + 1
+	}
+	return
+}
+
+// angNormalize -- reduces the angle `x` (degrees) to (-180,180].
+func angNormalize(x float64) float64 {
+	y := math.Remainder(x, 360)
+	if math.Abs(y) == 180 {
+		return math.Copysign(180, x)
+	}
+	return y
+}
+
+// angRound -- rounds tiny angles so that small values are represented exactly.
+func angRound(x float64) float64 {
+	const z = 1.0 / 16
+	y := math.Abs(x)
+	w := z - y
+	if w > 0 {
+		y = z - w
+	}
+	return math.Copysign(y, x)
+}
+
+// sum2 -- error-free transformation of a sum: u+v = s+t exactly.
+func sum2(u, v float64) (s, t float64) {
+	s = u + v
+	up := s - v
+	vpp := s - up
+	up -= u
+	vpp -= v
+	if s != 0 {
+		t = 0 - (up + vpp)
+	} else {
+		t = s
+	}
+	return
+}
+
+// angDiff -- computes y-x (degrees) reduced to [-180,180] exactly,
+// returned as the sum d+e.
+func angDiff(x, y float64) (d, e float64) {
+	d, e = sum2(math.Remainder(-x, 360), math.Remainder(y, 360))
+	d, e = sum2(math.Remainder(d, 360), e)
+	if d == 0 || math.Abs(d) == 180 {
+		if e == 0 {
+			d = math.Copysign(d, y-x)
+		} else {
+			d = math.Copysign(d, -e)
+		}
+	}
+	return
+}
+
+// atan2d -- computes atan2(y,x) in degrees with exact results
+// for the multiples of 45°.
+func atan2d(y, x float64) float64 {
+	q := 0
+	if math.Abs(y) > math.Abs(x) {
+		x, y = y, x
+		q = 2
+	}
+	if math.Signbit(x) {
+		x = -x
+		q++
+	}
+	ang := math.Atan2(y, x) * (180 / math.Pi)
+	switch q {
+	case 1:
+		ang = math.Copysign(180, y) - ang
+	case 2:
+		ang = 90 - ang
+	case 3:
+		ang = -90 + ang
+	}
+	return ang
+}
+
+// norm2 -- normalizes the vector (y,x) to unit length.
+func norm2(y, x float64) (float64, float64) {
+	r := math.Hypot(y, x)
+	return y / r, x / r
+}
+
+func merA0f(n float64) float64 {
+	const m = 3
+	coeff := [...]float64{1, 4, 64, 256, 256}
+	return polyval(m, coeff[:], 0, n*n) / coeff[m+1] / (1 + n)
+}
+
+func merCf(n float64) (C [7]float64) {
+	const nC = 6
+	coeff := [...]float64{-3, 18, -48, 32, 135, -960, 1920, 2048, 315, -560, 768, -189, 315, 512, -693, 1280, 1001, 2048}
+	n2, d := n*n, n
+	oo := 0
+	for L := 1; L <= nC; L++ {
+		m := (nC - L) / 2
+		C[L] = d * polyval(m, coeff[:], oo, n2) / coeff[oo+m+1]
+		oo += m + 2
+		d *= n
+	}
+	return
+}
+
+// sinc -- computes sin(x)/x.
+func sinc(x float64) float64 {
+	if x == 0 {
+		return 1
+	}
+	return math.Sin(x) / x
+}
+
+// dasinh -- computes the divided difference (asinh(y)-asinh(x))/(y-x).
+func dasinh(x, y float64) float64 {
+	d := y - x
+	hx, hy := math.Hypot(1, x), math.Hypot(1, y)
+	if d == 0 {
+		return 1 / hx
+	}
+	if x*y > 0 {
+		u := (x + y) / (x*hy + y*hx)
+		t := d * u
+		return math.Asinh(t) / t * u
+	}
+	return (math.Asinh(y) - math.Asinh(x)) / d
+}
+
+// datanh -- computes the divided difference (atanh(y)-atanh(x))/(y-x).
+func datanh(x, y float64) float64 {
+	d := y - x
+	u := 1 / (1 - x*y)
+	if d == 0 {
+		return u
+	}
+	t := d * u
+	return math.Atanh(t) / t * u
+}
+
+func ellC4x(n float64) (C4x [36]float64) {
+	const nC4 = 8
+	coeff := [...]float64{193, 85085, 4192, 850, 765765, 20960, -7888, 4947, 765765, 12480, -76160, 18496, 2652, 765765, -154048, 182512, -3808, -81328, 26741, 765765, 3232, 28288, -181152, 240448, -77792, -14586, 765765, 96, 272, 1088, 10608, -77792, 116688, -51051, 255255, 588, 952, 1700, 3536, 9724, 58344, -204204, 510510, 765765, 349, 2297295, -1472, 510, 459459, -39840, 1904, 255, 2297295, 52608, 65280, -50048, 7956, 2297295, 103744, -181968, 98464, 17680, -21879, 2297295, -1344, -13056, 101184, -198016, 155584, -43758, 2297295, -96, -272, -1088, -10608, 77792, -116688, 51051, 2297295, 464, 1276275, -928, -612, 3828825, 64256, -28288, 2856, 3828825, -126528, 28288, 31552, -15912, 3828825, -41472, 115328, -143616, 84864, -19448, 3828825, 160, 2176, -24480, 70720, -77792, 29172, 3828825, -16, 97461, -16384, 1088, 5360355, -2560, 30464, -11560, 5360355, 35840, -34816, 17408, -3536, 1786785, 7168, -30464, 60928, -56576, 19448, 5360355, 128, 2297295, 26624, -8704, 6891885, -77824, 34816, -6528, 6891885, -32256, 52224, -43520, 14144, 6891885, -6784, 8423415, 24576, -4352, 8423415, 45056, -34816, 10880, 8423415, -1024, 3318315, -28672, 8704, 9954945, 1024, 1640925}
+	oo, k := 0, 0
+	for L := 0; L < nC4; L++ {
+		for j := nC4 - 1; j >= L; j-- {
+			m := nC4 - j - 1
+			C4x[k] = polyval(m, coeff[:], oo, n) / coeff[oo+m+1]
+			k++
+			oo += m + 2
+		}
+	}
+	return
+}
+
+func ellC4f(C4x *[36]float64, eps float64) (C [8]float64) {
+	const nC4 = 8
+	d := 1.0
+	oo := 0
+	for L := 0; L < nC4; L++ {
+		m := nC4 - L - 1
+		C[L] = d * polyval(m, C4x[:], oo, eps)
+		oo += m + 1
+		d *= eps
+	}
+	return
+}
+
+func ellCosSeries(sin, cos float64, c []float64) float64 {
+	k := len(c)
+	n := k
+	ar := 2 * (cos - sin) * (cos + sin)
+	var y0, y1 float64
+	if (n & 1) != 0 {
+		k--
+		y0 = c[k]
+	}
+	n /= 2
+	for ; n != 0; n-- {
+		k--
+		y1 = ar*y0 - y1 + c[k]
+		k--
+		y0 = ar*y1 - y0 + c[k]
+	}
+	return cos * (y0 - y1)
+}
+
+// authq -- computes the authalic function q(φ) for the given sin(φ):
+//
+//	q(φ) = (1-e²)⋅(sin(φ)/(1-e²⋅sin²(φ)) + atanh(e⋅sin(φ))/e).
+func authq(e2, sinφ float64) float64 {
+	if e2 == 0 {
+		return 2 * sinφ
+	}
+	e := math.Sqrt(e2)
+	return (1 - e2) * (sinφ/(1-e2*sinφ*sinφ) + math.Atanh(e*sinφ)/e)
+}
+
+// glx, glw -- the nodes and the weights of the 16-point Gauss-Legendre quadrature.
+var glx, glw = gaussLegendre(16)
+
+// gaussLegendre -- computes the nodes and the weights
+// of the n-point Gauss-Legendre quadrature on [-1,1].
+func gaussLegendre(n int) (x, w []float64) {
+	x = make([]float64, n)
+	w = make([]float64, n)
+	for i := 0; i < (n+1)/2; i++ {
+		z := math.Cos(math.Pi * (float64(i) + 0.75) / (float64(n) + 0.5))
+		var dp float64
+		for it := 0; it < 100; it++ {
+			p0, p1 := 1.0, z
+			for k := 2; k <= n; k++ {
+				p0, p1 = p1, ((2*float64(k)-1)*z*p1-(float64(k)-1)*p0)/float64(k)
+			}
+			dp = float64(n) * (z*p1 - p0) / (z*z - 1)
+			dz := p1 / dp
+			z -= dz
+			if math.Abs(dz) <= mym.Epsilon {
+				break
+			}
+		}
+		x[i], x[n-1-i] = -z, z
+		w[i] = 2 / ((1 - z*z) * dp * dp)
+		w[n-1-i] = w[i]
+	}
+	return
+}
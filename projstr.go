@@ -0,0 +1,360 @@
+package geomys
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrPROJSyntax -- the error returned when a PROJ string is malformed.
+var ErrPROJSyntax = errors.New("invalid PROJ string syntax")
+
+// projKeys -- the PROJ parameters and the names of the corresponding Params() entries.
+var projKeys = map[string]string{
+	"lat_0":  "lat0",
+	"lon_0":  "lon0",
+	"lat_1":  "lat1",
+	"lat_2":  "lat2",
+	"lat_ts": "lat1",
+	"k_0":    "k0",
+	"k":      "k0",
+	"x_0":    "x0",
+	"y_0":    "y0",
+	"zone":   "zone",
+}
+
+// projEllps -- the PROJ ellipsoids and datums.
+var projEllps = map[string]func() Spheroid{
+	"GRS80":  GRS1980,
+	"GRS67":  GRS1967,
+	"WGS84":  WGS1984,
+	"WGS72":  WGS1972,
+	"intl":   International1924,
+	"clrk66": Clarke1866,
+	"airy":   func() Spheroid { return NewSpheroid(6377563.396, 1/299.3249646) },
+	"bessel": func() Spheroid { return NewSpheroid(6377397.155, 1/299.1528128) },
+}
+
+// projUnits -- the PROJ linear units.
+var projUnits = map[string]float64{
+	"m":     UnitMetre,
+	"km":    UnitKilometre,
+	"ft":    UnitFoot,
+	"us-ft": UnitUSSurveyFoot,
+}
+
+var projDatums = map[string]string{
+	"WGS84": "WGS84",
+	"NAD83": "GRS80",
+	"NAD27": "clrk66",
+}
+
+// projNames -- the PROJ projections and the names of the registered projections.
+var projNames = map[string]string{
+	"aea":      "aea",
+	"tmerc":    "tmerc",
+	"etmerc":   "tmerc",
+	"utm":      "utm",
+	"ups":      "utm",
+	"lcc":      "lcc",
+	"merc":     "merc",
+	"webmerc":  "webmerc",
+	"stere":    "stere_a",
+	"sterea":   "sterea",
+	"aeqd":     "aeqd",
+	"gnom":     "gnom",
+	"laea":     "laea",
+	"eqearth":  "eqearth",
+	"moll":     "moll",
+	"robin":    "robin",
+	"wintri":   "wintri",
+	"natearth": "natearth",
+}
+
+// ParsePROJ -- builds a map projection from the PROJ-style definition `def`, for example
+//
+//	+proj=aea +lat_1=29.5 +lat_2=45.5 +lat_0=23 +lon_0=-96 +ellps=GRS80
+//
+// The tokens are separated by white space, the leading '+' is optional.
+// The supported projections are aea, tmerc (etmerc), utm (+zone, +south), ups (+south),
+// lcc (one or two standard parallels), merc (+lat_ts or +k_0), webmerc, stere (polar, +lat_ts or +k_0),
+// sterea, aeqd, gnom, laea, eqearth, moll, robin, wintri, natearth, and any other
+// registered projection, whose parameters are named as in Params().
+// The spheroid is specified by +ellps (GRS80,GRS67,WGS84,WGS72,intl,clrk66,airy,bessel), by +datum
+// (WGS84,NAD83,NAD27), by +R, or by +a with one of +rf, +f, +b; the default is GRS80
+// (WGS84 for webmerc). The parameters +no_defs, +wktext and +type=crs are accepted and ignored.
+//
+// The linear unit given by +units (m,km,ft,us-ft) or +to_meter, the axis order +axis=neu,
+// and +x_0, +y_0 of a projection without its own false easting and northing
+// are the parameters of a Grid (unit, axis, fe, fn), see NewGrid.
+//
+// Returns an error wrapping ErrPROJSyntax, ErrUnknownProjection, ErrUnknownParam,
+// ErrMissingParam, or ErrInvalidParam, where the parameters are named as in `def`.
+func ParsePROJ(def string) (MapProjection, error) {
+	const fn = "geomys.ParsePROJ"
+	kv := make(map[string]string)
+	var order []string
+	for _, tok := range strings.Fields(def) {
+		tok = strings.TrimPrefix(tok, "+")
+		k, v, _ := strings.Cut(tok, "=")
+		if k == "" {
+			return nil, fmt.Errorf("%s: %w: `%s`", fn, ErrPROJSyntax, tok)
+		}
+		if _, ok := kv[k]; ok {
+			return nil, fmt.Errorf("%s: %w: `%s`", fn, ErrPROJSyntax, k)
+		}
+		kv[k] = v
+		order = append(order, k)
+	}
+	proj, ok := kv["proj"]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w: `proj`", fn, ErrMissingParam)
+	}
+	name, ok := projNames[proj]
+	if !ok {
+		name = proj
+	}
+	sph, err := projSpheroid(fn, kv, name == "webmerc")
+	if err != nil {
+		return nil, err
+	}
+	// the numeric parameters
+	par := make(map[string]float64)
+	names := make(map[string]string)
+	south := false
+	for _, k := range order {
+		v := kv[k]
+		switch k {
+		case "proj", "ellps", "datum", "R", "a", "b", "rf", "f", "no_defs", "wktext":
+			continue
+		case "type":
+			if v != "crs" {
+				return nil, fmt.Errorf("%s: %w: `%s`", fn, ErrInvalidParam, k)
+			}
+			continue
+		case "units", "to_meter":
+			unit, ok := projUnits[v]
+			if k == "to_meter" {
+				x, err := strconv.ParseFloat(v, 64)
+				unit, ok = x, err == nil
+			}
+			if _, dup := par["unit"]; dup || !ok {
+				return nil, fmt.Errorf("%s: %w: `%s`", fn, ErrInvalidParam, k)
+			}
+			par["unit"], names["unit"] = unit, k
+			continue
+		case "axis":
+			switch v {
+			case "enu":
+				par["axis"] = 0
+			case "neu":
+				par["axis"] = 1
+			default:
+				return nil, fmt.Errorf("%s: %w: `%s`", fn, ErrInvalidParam, k)
+			}
+			names["axis"] = k
+			continue
+		case "south":
+			if v != "" {
+				return nil, fmt.Errorf("%s: %w: `%s`", fn, ErrPROJSyntax, k)
+			}
+			south = true
+			continue
+		}
+		pk, ok := projKeys[k]
+		if !ok {
+			if _, known := projNames[proj]; known {
+				return nil, fmt.Errorf("%s: %w: `%s`", fn, ErrUnknownParam, k)
+			}
+			// a registered projection with the parameters named as in Params()
+			pk = k
+		}
+		if _, ok := par[pk]; ok {
+			return nil, fmt.Errorf("%s: %w: `%s`", fn, ErrPROJSyntax, k)
+		}
+		x, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w: `%s`", fn, ErrInvalidParam, k)
+		}
+		par[pk] = x
+		names[pk] = k
+	}
+	if south && !(proj == "utm" || proj == "ups") {
+		return nil, fmt.Errorf("%s: %w: `south`", fn, ErrUnknownParam)
+	}
+	// the variants of the projections
+	switch proj {
+	case "utm", "ups":
+		if proj == "ups" {
+			if _, ok := par["zone"]; ok {
+				return nil, fmt.Errorf("%s: %w: `zone`", fn, ErrUnknownParam)
+			}
+			par["zone"] = 0
+		}
+		par["north"] = 1
+		if south {
+			par["north"] = 0
+		}
+	case "lcc":
+		if _, ok := par["lat2"]; !ok {
+			// one standard parallel, the natural origin
+			lat1, ok1 := par["lat1"]
+			lat0, ok0 := par["lat0"]
+			switch {
+			case ok1 && ok0 && lat1 != lat0:
+				return nil, fmt.Errorf("%s: %w: `lat_0`", fn, ErrInvalidParam)
+			case ok1:
+				par["lat0"] = lat1
+				names["lat0"] = names["lat1"]
+				delete(par, "lat1")
+			}
+			name = "lcc1sp"
+		} else if k0, ok := par["k0"]; ok && k0 != 1 {
+			return nil, fmt.Errorf("%s: %w: `%s`", fn, ErrInvalidParam, names["k0"])
+		} else {
+			delete(par, "k0")
+		}
+	case "merc":
+		if k0, ok := par["k0"]; ok {
+			if _, ok := par["lat1"]; ok {
+				return nil, fmt.Errorf("%s: %w: `%s`", fn, ErrInvalidParam, names["k0"])
+			}
+			lat1, ok := mercLat1(sph, k0)
+			if !ok {
+				return nil, fmt.Errorf("%s: %w: `%s`", fn, ErrInvalidParam, names["k0"])
+			}
+			par["lat1"] = lat1
+			names["lat1"] = names["k0"]
+			delete(par, "k0")
+		}
+	case "stere":
+		lat0, ok := par["lat0"]
+		if !ok || math.Abs(lat0) != 90 {
+			// the oblique stereographic projection is sterea
+			return nil, fmt.Errorf("%s: %w: `lat_0`", fn, ErrInvalidParam)
+		}
+		if lat1, ok := par["lat1"]; ok {
+			if _, ok := par["k0"]; ok || lat1*lat0 <= 0 {
+				return nil, fmt.Errorf("%s: %w: `%s`", fn, ErrInvalidParam, names["lat1"])
+			}
+			delete(par, "lat0")
+			name = "stere_b"
+		}
+	}
+	// the false origin of a projection without x0 and y0
+	if params, ok := ProjectionParams(name); ok {
+		has := make(map[string]bool)
+		for _, pp := range params {
+			has[pp.Name] = true
+		}
+		for _, k := range [][2]string{{"x0", "fe"}, {"y0", "fn"}} {
+			if v, ok := par[k[0]]; ok && !has[k[0]] {
+				par[k[1]], names[k[1]] = v, names[k[0]]
+				delete(par, k[0])
+			}
+		}
+	}
+	return newProjection(fn, name, sph, par, func(k string) string {
+		if pk, ok := names[k]; ok {
+			return pk
+		}
+		for pk, v := range projKeys {
+			if v == k && pk != "lat_ts" && pk != "k" {
+				return pk
+			}
+		}
+		return k
+	})
+}
+
+// projSpheroid -- returns the spheroid specified by the PROJ parameters `kv`.
+func projSpheroid(fn string, kv map[string]string, wgs84 bool) (Spheroid, error) {
+	num := func(k string) (float64, bool, error) {
+		v, ok := kv[k]
+		if !ok {
+			return 0, false, nil
+		}
+		x, err := strconv.ParseFloat(v, 64)
+		if err != nil || !(x >= 0 && x < math.Inf(1)) {
+			return 0, true, fmt.Errorf("%s: %w: `%s`", fn, ErrInvalidParam, k)
+		}
+		return x, true, nil
+	}
+	// exactly one of ellps, datum, R, a
+	var given []string
+	for _, k := range []string{"ellps", "datum", "R", "a"} {
+		if _, ok := kv[k]; ok {
+			given = append(given, k)
+		}
+	}
+	if len(given) > 1 {
+		return Spheroid{}, fmt.Errorf("%s: %w: `%s`", fn, ErrInvalidParam, given[1])
+	}
+	for _, k := range []string{"b", "rf", "f"} {
+		if _, ok := kv[k]; ok && !(len(given) == 1 && given[0] == "a") {
+			return Spheroid{}, fmt.Errorf("%s: %w: `%s`", fn, ErrInvalidParam, k)
+		}
+	}
+	if len(given) == 0 {
+		if wgs84 {
+			return WGS1984(), nil
+		}
+		return GRS1980(), nil
+	}
+	switch k := given[0]; k {
+	case "ellps", "datum":
+		ellps := kv[k]
+		if k == "datum" {
+			ellps = projDatums[ellps]
+		}
+		sph, ok := projEllps[ellps]
+		if !ok {
+			return Spheroid{}, fmt.Errorf("%s: %w: `%s`", fn, ErrInvalidParam, k)
+		}
+		return sph(), nil
+	case "R":
+		r, _, err := num("R")
+		if err != nil {
+			return Spheroid{}, err
+		}
+		if !(1 <= r && r <= 1e22) {
+			return Spheroid{}, fmt.Errorf("%s: %w: `R`", fn, ErrInvalidParam)
+		}
+		return NewSphere(r), nil
+	}
+	a, _, err := num("a")
+	if err != nil {
+		return Spheroid{}, err
+	}
+	f, fk := 0.0, "a"
+	if rf, ok, err := num("rf"); err != nil {
+		return Spheroid{}, err
+	} else if ok {
+		f, fk = 1/rf, "rf"
+	}
+	if x, ok, err := num("f"); err != nil {
+		return Spheroid{}, err
+	} else if ok {
+		if fk != "a" {
+			return Spheroid{}, fmt.Errorf("%s: %w: `f`", fn, ErrInvalidParam)
+		}
+		f, fk = x, "f"
+	}
+	if b, ok, err := num("b"); err != nil {
+		return Spheroid{}, err
+	} else if ok {
+		if fk != "a" {
+			return Spheroid{}, fmt.Errorf("%s: %w: `b`", fn, ErrInvalidParam)
+		}
+		f, fk = (a-b)/a, "b"
+	}
+	if !(1 <= a && a <= 1e22) {
+		return Spheroid{}, fmt.Errorf("%s: %w: `a`", fn, ErrInvalidParam)
+	}
+	if !(0 <= f && f <= 1.0/150.0) {
+		return Spheroid{}, fmt.Errorf("%s: %w: `%s`", fn, ErrInvalidParam, fk)
+	}
+	return NewSpheroid(a, f), nil
+}
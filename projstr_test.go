@@ -0,0 +1,153 @@
+package geomys
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePROJ(t *testing.T) {
+	airyA, airyB := 6377563.396, 6356256.909
+	tests := []struct {
+		def string
+		prj MapProjection
+	}{
+		{"+proj=aea +lat_1=29.5 +lat_2=45.5 +ellps=GRS80",
+			NewAlbers(GRS1980(), 29.5, 45.5, 0, 0)},
+		{"+proj=aea +lat_0=23 +lon_0=-96 +lat_1=29.5 +lat_2=45.5 +datum=NAD27 +units=m +no_defs +type=crs",
+			NewAlbers(Clarke1866(), 29.5, 45.5, 23, -96)},
+		// EPSG:32733
+		{"+proj=utm +zone=33 +south +datum=WGS84 +units=m +no_defs",
+			NewUTM(WGS1984(), 33, false)},
+		{"+proj=ups +ellps=WGS84", NewUTM(WGS1984(), 0, true)},
+		// EPSG:27700
+		{"+proj=tmerc +lat_0=49 +lon_0=-2 +k=0.9996012717 +x_0=400000 +y_0=-100000 +ellps=airy",
+			NewTransverseMercator(NewSpheroid(6377563.396, 1/299.3249646), 49, -2, 0.9996012717, 4e5, -1e5)},
+		{"+proj=tmerc +lat_0=49 +lon_0=-2 +k=0.9996012717 +x_0=400000 +y_0=-100000 +a=6377563.396 +rf=299.3249646",
+			NewTransverseMercator(NewSpheroid(6377563.396, 1/299.3249646), 49, -2, 0.9996012717, 4e5, -1e5)},
+		{"+proj=tmerc +lon_0=9 +a=6377563.396 +b=6356256.909",
+			NewTransverseMercator(NewSpheroid(airyA, (airyA-airyB)/airyA), 0, 9, 1, 0, 0)},
+		// EPSG:24200
+		{"+proj=lcc +lat_1=18 +lat_0=18 +lon_0=-77 +k_0=1 +x_0=250000 +y_0=150000 +ellps=clrk66",
+			NewLambertConformalConic1SP(Clarke1866(), 18, -77, 1, 250000, 150000)},
+		{"+proj=lcc +lat_1=33 +lat_2=45 +lat_0=23 +lon_0=-96 +x_0=0 +y_0=0 +datum=NAD83",
+			NewLambertConformalConic(GRS1980(), 33, 45, 23, -96, 0, 0)},
+		// EPSG:3395 and EPSG:3857
+		{"+proj=merc +lon_0=0 +k=1 +x_0=0 +y_0=0 +datum=WGS84",
+			NewMercator(WGS1984(), 0, 0, 0, 0)},
+		{"+proj=merc +lat_ts=42 +lon_0=51 +ellps=WGS84",
+			NewMercator(WGS1984(), 42, 51, 0, 0)},
+		{"+proj=webmerc", NewWebMercator()},
+		// EPSG:3031 and EPSG:5041
+		{"+proj=stere +lat_0=-90 +lat_ts=-71 +lon_0=0 +x_0=0 +y_0=0 +datum=WGS84",
+			NewPolarStereographicB(WGS1984(), -71, 0, 0, 0)},
+		{"+proj=stere +lat_0=90 +lon_0=0 +k=0.994 +x_0=2000000 +y_0=2000000 +datum=WGS84",
+			NewPolarStereographicA(WGS1984(), 90, 0, 0.994, 2e6, 2e6)},
+		// EPSG:28992
+		{"+proj=sterea +lat_0=52.15616055555555 +lon_0=5.38763888888889 +k=0.9999079 +x_0=155000 +y_0=463000 +ellps=bessel",
+			NewObliqueStereographic(NewSpheroid(6377397.155, 1/299.1528128), 52.15616055555555, 5.38763888888889, 0.9999079, 155000, 463000)},
+		{"+proj=laea +lat_0=52 +lon_0=10 +x_0=4321000 +y_0=3210000 +ellps=GRS80",
+			NewLambertAzimuthalEqualArea(GRS1980(), Geo(52, 10, 0), 4321000, 3210000)},
+		{"+proj=aeqd +lat_0=40 +lon_0=-100 +R=6371000",
+			NewAzimuthalEquidistant(NewSphere(6371000), Geo(40, -100, 0), 0, 0)},
+		{"+proj=aeqd +lat_0=-30 +lon_0=20 +x_0=1000 +y_0=2000",
+			NewAzimuthalEquidistant(GRS1980(), Geo(-30, 20, 0), 1000, 2000)},
+		{"proj=eqearth lon_0=150 ellps=WGS84", NewEqualEarth(WGS1984(), 150)},
+		{"+proj=robin", NewRobinson(GRS1980(), 0)},
+	}
+	for _, tt := range tests {
+		prj, err := ParsePROJ(tt.def)
+		if err != nil {
+			t.Errorf("%q: %v", tt.def, err)
+			continue
+		}
+		if prj.Spheroid() != tt.prj.Spheroid() {
+			t.Errorf("%q: got %v, want %v", tt.def, prj.Spheroid(), tt.prj.Spheroid())
+		}
+		for _, p := range []Point{Geo(35, -75, 0), Geo(-75, 30, 0), Geo(52, 5, 0)} {
+			if xy, want := prj.Project(p), tt.prj.Project(p); xy != want {
+				t.Errorf("%q: got %v, want %v", tt.def, xy, want)
+			}
+		}
+	}
+}
+
+func TestParsePROJGrid(t *testing.T) {
+	tests := []struct {
+		def string
+		prj MapProjection
+	}{
+		// EPSG:2227
+		{"+proj=lcc +lat_0=36.5 +lon_0=-120.5 +lat_1=38.4333333333333 +lat_2=37.0666666666667 +x_0=2000000.0001016 +y_0=500000.0001016 +ellps=GRS80 +units=us-ft",
+			NewGrid(NewLambertConformalConic(GRS1980(), 38.4333333333333, 37.0666666666667, 36.5, -120.5, 2000000.0001016, 500000.0001016), 0, 0, UnitUSSurveyFoot, false)},
+		{"+proj=aea +lat_1=29.5 +lat_2=45.5 +lat_0=23 +lon_0=-96 +x_0=1000 +y_0=-2000 +to_meter=0.3048 +axis=neu",
+			NewGrid(NewAlbers(GRS1980(), 29.5, 45.5, 23, -96), 1000, -2000, UnitFoot, true)},
+		{"+proj=robin +units=km", NewGrid(NewRobinson(GRS1980(), 0), 0, 0, UnitKilometre, false)},
+	}
+	for _, tt := range tests {
+		prj, err := ParsePROJ(tt.def)
+		if err != nil {
+			t.Errorf("%q: %v", tt.def, err)
+			continue
+		}
+		if !reflect.DeepEqual(prj.Params(), tt.prj.Params()) {
+			t.Errorf("%q: got %v, want %v", tt.def, prj.Params(), tt.prj.Params())
+		}
+	}
+}
+
+func TestParsePROJMercatorK0(t *testing.T) {
+	prj, err := ParsePROJ("+proj=merc +k_0=0.997 +lon_0=110 +ellps=WGS84")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, k := prj.(Mercator).ProjectExt(Geo(0, 110, 0)); math.Abs(k-0.997) > 1e-15 {
+		t.Errorf("k0: got %v", k)
+	}
+}
+
+func TestParsePROJErrors(t *testing.T) {
+	tests := []struct {
+		def string
+		err error
+		bad string
+	}{
+		{"+lat_1=29.5 +lat_2=45.5", ErrMissingParam, "proj"},
+		{"+proj=aea +lat_1=29.5", ErrMissingParam, "lat_2"},
+		{"+proj=utm +south", ErrMissingParam, "zone"},
+		{"+proj=aea +lat_1=29.5 +lat_2=45.5 +=1", ErrPROJSyntax, "=1"},
+		{"+proj=aea +lat_1=29.5 +lat_1=45.5", ErrPROJSyntax, "lat_1"},
+		{"+proj=merc +lat_1=10 +lat_ts=10", ErrPROJSyntax, "lat_ts"},
+		{"+proj=aea +lat_1=29.5 +lat_2=45.5 +towgs84=0,0,0", ErrUnknownParam, "towgs84"},
+		{"+proj=tmerc +south", ErrUnknownParam, "south"},
+		{"+proj=bonne +lat_1=45", ErrUnknownProjection, "bonne"},
+		{"+proj=aea +lat_1=abc +lat_2=45.5", ErrInvalidParam, "lat_1"},
+		{"+proj=aea +lat_1=95 +lat_2=45.5", ErrInvalidParam, "lat_1"},
+		{"+proj=aea +lat_1=90 +lat_2=90", ErrInvalidParam, "lat_2"},
+		{"+proj=aea +lat_1=30 +lat_2=30", ErrInvalidParam, "lat_2"},
+		{"+proj=lcc +lat_1=30 +lat_2=40 +lat_0=-90", ErrInvalidParam, "lat_0"},
+		{"+proj=tmerc +k=0", ErrInvalidParam, "k"},
+		{"+proj=tmerc +units=yd", ErrInvalidParam, "units"},
+		{"+proj=tmerc +to_meter=0", ErrInvalidParam, "to_meter"},
+		{"+proj=tmerc +units=ft +to_meter=0.3048", ErrInvalidParam, "to_meter"},
+		{"+proj=tmerc +axis=wsu", ErrInvalidParam, "axis"},
+		{"+proj=tmerc +ellps=GRS80 +a=6378137", ErrInvalidParam, "a"},
+		{"+proj=tmerc +ellps=foo", ErrInvalidParam, "ellps"},
+		{"+proj=tmerc +datum=ED50", ErrInvalidParam, "datum"},
+		{"+proj=tmerc +rf=298", ErrInvalidParam, "rf"},
+		{"+proj=tmerc +a=6378137 +rf=10", ErrInvalidParam, "rf"},
+		{"+proj=lcc +lat_1=18 +lat_0=20", ErrInvalidParam, "lat_0"},
+		{"+proj=lcc +lat_1=33 +lat_2=45 +k_0=0.9", ErrInvalidParam, "k_0"},
+		{"+proj=stere +lat_0=45", ErrInvalidParam, "lat_0"},
+		{"+proj=stere +lat_0=90 +lat_ts=-71", ErrInvalidParam, "lat_ts"},
+		{"+proj=webmerc +ellps=GRS80", ErrInvalidParam, "sph"},
+	}
+	for _, tt := range tests {
+		_, err := ParsePROJ(tt.def)
+		if !errors.Is(err, tt.err) || !strings.Contains(err.Error(), "`"+tt.bad+"`") {
+			t.Errorf("%q: got %v", tt.def, err)
+		}
+	}
+}
@@ -0,0 +1,422 @@
+package geomys
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// The errors returned when a map projection cannot be built from its parameters.
+var (
+	ErrUnknownProjection = errors.New("unknown projection")
+	ErrUnknownParam      = errors.New("unknown parameter")
+	ErrMissingParam      = errors.New("missing parameter")
+	ErrInvalidParam      = errors.New("invalid parameter value")
+)
+
+// ProjParam -- describes a parameter of a registered map projection.
+type ProjParam struct {
+	Name     string  // the name of the parameter as in Params()
+	Required bool    // true when the parameter has no default value
+	Default  float64 // the default value, NaN for a value derived from the other parameters
+}
+
+// ProjFactory -- builds a map projection based on the spheroid `sph` from the parameters `par`,
+// which contain all the parameters of the projection except the absent ones with the default NaN.
+type ProjFactory func(sph Spheroid, par map[string]float64) (MapProjection, error)
+
+type projEntry struct {
+	params  []ProjParam
+	factory ProjFactory
+}
+
+var (
+	projMutex    sync.RWMutex
+	projRegistry = builtinProjections()
+)
+
+// RegisterProjection -- registers the map projection `name` with the parameters `params`
+// built by `factory`. This function causes a runtime panic when `name` is empty
+// or already registered, a parameter name is empty, repeated, or a grid parameter
+// (fe,fn,unit,axis), or `factory` is nil.
+func RegisterProjection(name string, params []ProjParam, factory ProjFactory) {
+	if name == "" {
+		panic("geomys.RegisterProjection: domain error: `name`")
+	}
+	if factory == nil {
+		panic("geomys.RegisterProjection: domain error: `factory`")
+	}
+	seen := make(map[string]bool)
+	for _, k := range gridKeys {
+		seen[k] = true
+	}
+	for _, pp := range params {
+		if pp.Name == "" || seen[pp.Name] {
+			panic("geomys.RegisterProjection: domain error: `params`")
+		}
+		seen[pp.Name] = true
+	}
+	//
+	projMutex.Lock()
+	defer projMutex.Unlock()
+	if _, ok := projRegistry[name]; ok {
+		panic("geomys.RegisterProjection: domain error: `name`")
+	}
+	projRegistry[name] = projEntry{append([]ProjParam(nil), params...), factory}
+}
+
+// ProjectionNames -- returns the sorted names of the registered map projections.
+func ProjectionNames() []string {
+	projMutex.RLock()
+	defer projMutex.RUnlock()
+	names := make([]string, 0, len(projRegistry))
+	for name := range projRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ProjectionParams -- returns the parameters of the registered map projection `name`.
+// When `name` is not registered, sets `ok` to false.
+func ProjectionParams(name string) (params []ProjParam, ok bool) {
+	projMutex.RLock()
+	defer projMutex.RUnlock()
+	e, ok := projRegistry[name]
+	if !ok {
+		return nil, false
+	}
+	return append([]ProjParam(nil), e.params...), true
+}
+
+// NewProjection -- builds the registered map projection `name` based on the spheroid `sph`
+// from the parameters `par` named as in Params(), so that NewProjection(name,prj.Spheroid(),prj.Params())
+// rebuilds `prj`. The missing optional parameters take their default values.
+// The parameters named lat* must be in [-90,90], the parameters named lon* must be in [-180,180].
+// When any of the grid parameters fe,fn,unit,axis is present, the projection is wrapped
+// by NewGrid, the missing grid parameters are fe=0, fn=0, unit=1, axis=0.
+// Returns an error wrapping ErrUnknownProjection, ErrUnknownParam, ErrMissingParam,
+// ErrInvalidParam, or the error of the factory, when the projection cannot be built.
+func NewProjection(name string, sph Spheroid, par map[string]float64) (MapProjection, error) {
+	return newProjection("geomys.NewProjection", name, sph, par, func(k string) string { return k })
+}
+
+// newProjection -- implements NewProjection, the errors are reported by the function `fn`
+// and the parameter `k` is reported as key(k).
+func newProjection(fn, name string, sph Spheroid, par map[string]float64, key func(string) string) (MapProjection, error) {
+	projMutex.RLock()
+	e, ok := projRegistry[name]
+	projMutex.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%s: %w: `%s`", fn, ErrUnknownProjection, name)
+	}
+	//
+	par, grid := splitGridParams(par)
+	known := make(map[string]bool)
+	for _, pp := range e.params {
+		known[pp.Name] = true
+	}
+	for _, k := range sortedKeys(par) {
+		if !known[k] {
+			return nil, fmt.Errorf("%s: %w: `%s`", fn, ErrUnknownParam, key(k))
+		}
+	}
+	full := make(map[string]float64)
+	for _, pp := range e.params {
+		v, ok := par[pp.Name]
+		switch {
+		case ok:
+		case pp.Required:
+			return nil, fmt.Errorf("%s: %w: `%s`", fn, ErrMissingParam, key(pp.Name))
+		case math.IsNaN(pp.Default):
+			continue
+		default:
+			v = pp.Default
+		}
+		if !projParamValid(pp.Name, v) {
+			return nil, fmt.Errorf("%s: %w: `%s`", fn, ErrInvalidParam, key(pp.Name))
+		}
+		full[pp.Name] = v
+	}
+	prj, err := e.factory(sph, full)
+	var perr *projParamError
+	switch {
+	case errors.As(err, &perr):
+		return nil, fmt.Errorf("%s: %w: `%s`", fn, ErrInvalidParam, key(perr.name))
+	case err != nil:
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	case grid == nil:
+		return prj, nil
+	}
+	for _, k := range sortedKeys(grid) {
+		if !projParamValid(k, grid[k]) {
+			return nil, fmt.Errorf("%s: %w: `%s`", fn, ErrInvalidParam, key(k))
+		}
+	}
+	unit, ok := grid["unit"]
+	if !ok {
+		unit = 1
+	}
+	if !(unit > 0) {
+		return nil, fmt.Errorf("%s: %w: `%s`", fn, ErrInvalidParam, key("unit"))
+	}
+	if axis, ok := grid["axis"]; ok && !(axis == 0 || axis == 1) {
+		return nil, fmt.Errorf("%s: %w: `%s`", fn, ErrInvalidParam, key("axis"))
+	}
+	return NewGrid(prj, grid["fe"], grid["fn"], unit, grid["axis"] == 1), nil
+}
+
+// projParamValid -- checks that the value `v` of the parameter `name` is finite,
+// and that the latitudes and the longitudes are within their ranges.
+func projParamValid(name string, v float64) bool {
+	switch {
+	case math.IsNaN(v) || math.IsInf(v, 0):
+		return false
+	case strings.HasPrefix(name, "lat"):
+		return -90 <= v && v <= 90
+	case strings.HasPrefix(name, "lon"):
+		return -180 <= v && v <= 180
+	}
+	return true
+}
+
+// projParamError -- the error of a built-in factory for the invalid value of the parameter `name`,
+// newProjection reports it under the name used by its caller.
+type projParamError struct {
+	name string
+}
+
+func (e *projParamError) Error() string {
+	return fmt.Sprintf("%v: `%s`", ErrInvalidParam, e.name)
+}
+
+func (e *projParamError) Unwrap() error {
+	return ErrInvalidParam
+}
+
+// invalidParam -- returns the error for the invalid value of the parameter `name`.
+func invalidParam(name string) error {
+	return &projParamError{name}
+}
+
+func sortedKeys(par map[string]float64) []string {
+	keys := make([]string, 0, len(par))
+	for k := range par {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// builtinProjections -- returns the registry of the map projections provided by this package.
+func builtinProjections() map[string]projEntry {
+	req := func(name string) ProjParam { return ProjParam{Name: name, Required: true} }
+	opt := func(name string, v float64) ProjParam { return ProjParam{Name: name, Default: v} }
+	lon0 := []ProjParam{opt("lon0", 0)}
+	center := []ProjParam{opt("lat0", 0), opt("lon0", 0), opt("x0", 0), opt("y0", 0)}
+	nan := math.NaN()
+	//
+	return map[string]projEntry{
+		"aea": {[]ProjParam{req("lat1"), req("lat2"), opt("lat0", 0), opt("lon0", 0)},
+			func(sph Spheroid, par map[string]float64) (MapProjection, error) {
+				// the standard parallels determine the cone, its constant n must be nonzero
+				prj := NewAlbers(sph, par["lat1"], par["lat2"], par["lat0"], par["lon0"])
+				if n := prj.n; n == 0 || math.IsNaN(n) || math.IsInf(n, 0) {
+					return nil, invalidParam("lat2")
+				}
+				if math.IsNaN(prj.ρ0) || math.IsInf(prj.ρ0, 0) {
+					return nil, invalidParam("lat0")
+				}
+				return prj, nil
+			}},
+		"tmerc": {[]ProjParam{opt("lat0", 0), opt("lon0", 0), opt("k0", 1), opt("x0", 0), opt("y0", 0)},
+			func(sph Spheroid, par map[string]float64) (MapProjection, error) {
+				if !(par["k0"] > 0) {
+					return nil, invalidParam("k0")
+				}
+				return NewTransverseMercator(sph, par["lat0"], par["lon0"], par["k0"], par["x0"], par["y0"]), nil
+			}},
+		"utm": {[]ProjParam{req("zone"), opt("north", 1),
+			opt("lat0", nan), opt("lon0", nan), opt("k0", nan), opt("x0", nan), opt("y0", nan)},
+			func(sph Spheroid, par map[string]float64) (MapProjection, error) {
+				zone, north := par["zone"], par["north"]
+				if !(zone == math.Trunc(zone) && 0 <= zone && zone <= 60) {
+					return nil, invalidParam("zone")
+				}
+				if !(north == 0 || north == 1) {
+					return nil, invalidParam("north")
+				}
+				prj := NewUTM(sph, int(zone), north == 1)
+				// the derived parameters must agree with the zone
+				derived := prj.Params()
+				for _, k := range sortedKeys(derived) {
+					if w, ok := par[k]; ok && w != derived[k] {
+						return nil, invalidParam(k)
+					}
+				}
+				return prj, nil
+			}},
+		"lcc": {[]ProjParam{req("lat1"), req("lat2"), opt("lat0", 0), opt("lon0", 0), opt("x0", 0), opt("y0", 0)},
+			func(sph Spheroid, par map[string]float64) (MapProjection, error) {
+				lat1, lat2, lat0 := par["lat1"], par["lat2"], par["lat0"]
+				if !(-90 < lat1 && lat1 < 90) {
+					return nil, invalidParam("lat1")
+				}
+				if !(-90 < lat2 && lat2 < 90) || lat1 == -lat2 {
+					return nil, invalidParam("lat2")
+				}
+				// the apex of the cone is at the pole in the hemisphere of the standard parallels
+				if math.Abs(lat0) == 90 && lat0*(lat1+lat2) < 0 {
+					return nil, invalidParam("lat0")
+				}
+				return NewLambertConformalConic(sph, par["lat1"], par["lat2"], par["lat0"], par["lon0"], par["x0"], par["y0"]), nil
+			}},
+		"lcc1sp": {[]ProjParam{req("lat0"), opt("lon0", 0), opt("k0", 1), opt("x0", 0), opt("y0", 0)},
+			func(sph Spheroid, par map[string]float64) (MapProjection, error) {
+				if lat0 := par["lat0"]; !(-90 < lat0 && lat0 < 90) || lat0 == 0 {
+					return nil, invalidParam("lat0")
+				}
+				if !(par["k0"] > 0) {
+					return nil, invalidParam("k0")
+				}
+				return NewLambertConformalConic1SP(sph, par["lat0"], par["lon0"], par["k0"], par["x0"], par["y0"]), nil
+			}},
+		"merc": {[]ProjParam{opt("lat1", 0), opt("lon0", 0), opt("x0", 0), opt("y0", 0)},
+			func(sph Spheroid, par map[string]float64) (MapProjection, error) {
+				if lat1 := par["lat1"]; !(-90 < lat1 && lat1 < 90) {
+					return nil, invalidParam("lat1")
+				}
+				return NewMercator(sph, par["lat1"], par["lon0"], par["x0"], par["y0"]), nil
+			}},
+		"webmerc": {[]ProjParam{opt("lat1", 0), opt("lon0", 0), opt("x0", 0), opt("y0", 0)},
+			func(sph Spheroid, par map[string]float64) (MapProjection, error) {
+				for _, k := range []string{"lat1", "lon0", "x0", "y0"} {
+					if par[k] != 0 {
+						return nil, invalidParam(k)
+					}
+				}
+				if sph != WGS1984() {
+					return nil, invalidParam("sph")
+				}
+				return NewWebMercator(), nil
+			}},
+		"stere_a": {[]ProjParam{req("lat0"), opt("lon0", 0), opt("k0", 1), opt("x0", 0), opt("y0", 0)},
+			func(sph Spheroid, par map[string]float64) (MapProjection, error) {
+				if math.Abs(par["lat0"]) != 90 {
+					return nil, invalidParam("lat0")
+				}
+				if !(par["k0"] > 0) {
+					return nil, invalidParam("k0")
+				}
+				return NewPolarStereographicA(sph, par["lat0"], par["lon0"], par["k0"], par["x0"], par["y0"]), nil
+			}},
+		"stere_b": {[]ProjParam{req("lat1"), opt("lon0", 0), opt("x0", 0), opt("y0", 0)},
+			func(sph Spheroid, par map[string]float64) (MapProjection, error) {
+				if par["lat1"] == 0 {
+					return nil, invalidParam("lat1")
+				}
+				return NewPolarStereographicB(sph, par["lat1"], par["lon0"], par["x0"], par["y0"]), nil
+			}},
+		"stere_c": {[]ProjParam{req("lat1"), opt("lon0", 0), opt("x0", 0), opt("y0", 0)},
+			func(sph Spheroid, par map[string]float64) (MapProjection, error) {
+				if par["lat1"] == 0 {
+					return nil, invalidParam("lat1")
+				}
+				return NewPolarStereographicC(sph, par["lat1"], par["lon0"], par["x0"], par["y0"]), nil
+			}},
+		"sterea": {[]ProjParam{req("lat0"), opt("lon0", 0), opt("k0", 1), opt("x0", 0), opt("y0", 0)},
+			func(sph Spheroid, par map[string]float64) (MapProjection, error) {
+				if lat0 := par["lat0"]; !(-90 < lat0 && lat0 < 90) {
+					return nil, invalidParam("lat0")
+				}
+				if !(par["k0"] > 0) {
+					return nil, invalidParam("k0")
+				}
+				return NewObliqueStereographic(sph, par["lat0"], par["lon0"], par["k0"], par["x0"], par["y0"]), nil
+			}},
+		"aeqd": {center,
+			func(sph Spheroid, par map[string]float64) (MapProjection, error) {
+				return NewAzimuthalEquidistant(sph, Geo(par["lat0"], par["lon0"], 0.0), par["x0"], par["y0"]), nil
+			}},
+		"gnom": {center,
+			func(sph Spheroid, par map[string]float64) (MapProjection, error) {
+				return NewGnomonic(sph, Geo(par["lat0"], par["lon0"], 0.0), par["x0"], par["y0"]), nil
+			}},
+		"laea": {center,
+			func(sph Spheroid, par map[string]float64) (MapProjection, error) {
+				return NewLambertAzimuthalEqualArea(sph, Geo(par["lat0"], par["lon0"], 0.0), par["x0"], par["y0"]), nil
+			}},
+		"eqearth": {lon0,
+			func(sph Spheroid, par map[string]float64) (MapProjection, error) {
+				return NewEqualEarth(sph, par["lon0"]), nil
+			}},
+		"moll": {lon0,
+			func(sph Spheroid, par map[string]float64) (MapProjection, error) {
+				return NewMollweide(sph, par["lon0"]), nil
+			}},
+		"robin": {lon0,
+			func(sph Spheroid, par map[string]float64) (MapProjection, error) {
+				return NewRobinson(sph, par["lon0"]), nil
+			}},
+		"wintri": {lon0,
+			func(sph Spheroid, par map[string]float64) (MapProjection, error) {
+				return NewWinkelTripel(sph, par["lon0"]), nil
+			}},
+		"natearth": {lon0,
+			func(sph Spheroid, par map[string]float64) (MapProjection, error) {
+				return NewNaturalEarth(sph, par["lon0"]), nil
+			}},
+	}
+}
+
+// projectionName -- returns the registered name of the map projection `prj`
+// provided by this package. When `prj` is of another type, sets `ok` to false.
+func projectionName(prj MapProjection) (name string, ok bool) {
+	switch prj := prj.(type) {
+	case Albers:
+		return "aea", true
+	case TransverseMercator:
+		return "tmerc", true
+	case UTM:
+		return "utm", true
+	case LambertConformalConic:
+		if _, ok := prj.Params()["lat1"]; ok {
+			return "lcc", true
+		}
+		return "lcc1sp", true
+	case Mercator:
+		return "merc", true
+	case WebMercator:
+		return "webmerc", true
+	case PolarStereographic:
+		switch _, ok := prj.Params()["k0"]; {
+		case ok:
+			return "stere_a", true
+		case prj.ρF != 0:
+			return "stere_c", true
+		}
+		return "stere_b", true
+	case ObliqueStereographic:
+		return "sterea", true
+	case AzimuthalEquidistant:
+		return "aeqd", true
+	case Gnomonic:
+		return "gnom", true
+	case LambertAzimuthalEqualArea:
+		return "laea", true
+	case EqualEarth:
+		return "eqearth", true
+	case Mollweide:
+		return "moll", true
+	case Robinson:
+		return "robin", true
+	case WinkelTripel:
+		return "wintri", true
+	case NaturalEarth:
+		return "natearth", true
+	}
+	return "", false
+}
@@ -0,0 +1,135 @@
+package geomys
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestNewProjectionParams(t *testing.T) {
+	sph := GRS1980()
+	prjs := map[string]MapProjection{
+		"aea":      NewAlbers(sph, 29.5, 45.5, 23, -96),
+		"tmerc":    NewTransverseMercator(sph, 49, -2, 0.9996012717, 4e5, -1e5),
+		"utm":      NewUTM(sph, 33, false),
+		"lcc":      NewLambertConformalConic(sph, 33, 45, 23, -96, 1e6, 0),
+		"lcc1sp":   NewLambertConformalConic1SP(sph, 18, -77, 1, 250000, 150000),
+		"merc":     NewMercator(sph, 42, 51, 0, 0),
+		"webmerc":  NewWebMercator(),
+		"stere_a":  NewPolarStereographicA(sph, 90, 0, 0.994, 2e6, 2e6),
+		"stere_b":  NewPolarStereographicB(sph, -71, 70, 6e6, 4e6),
+		"stere_c":  NewPolarStereographicC(sph, -67, 140, 3e5, 2e5),
+		"sterea":   NewObliqueStereographic(sph, 52.15616055555555, 5.38763888888889, 0.9999079, 155000, 463000),
+		"aeqd":     NewAzimuthalEquidistant(sph, Geo(40, -100, 0), 1e5, 2e5),
+		"gnom":     NewGnomonic(sph, Geo(-30, 20, 0), -3e5, 0),
+		"laea":     NewLambertAzimuthalEqualArea(sph, Geo(52, 10, 0), 4321000, 3210000),
+		"eqearth":  NewEqualEarth(sph, 150),
+		"moll":     NewMollweide(sph, -30),
+		"robin":    NewRobinson(sph, 10),
+		"wintri":   NewWinkelTripel(sph, 0),
+		"natearth": NewNaturalEarth(sph, -90),
+	}
+	if names := ProjectionNames(); len(names) < len(prjs) {
+		t.Fatalf("ProjectionNames: got %v", names)
+	}
+	for name, prj := range prjs {
+		prj2, err := NewProjection(name, prj.Spheroid(), prj.Params())
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		for _, p := range []Point{Geo(35, -75, 0), Geo(-60, 100, 0), Geo(75, 5, 0)} {
+			xy, xy2 := prj.Project(p), prj2.Project(p)
+			if !(xy == xy2 || math.IsNaN(xy[0]) && math.IsNaN(xy2[0])) {
+				t.Errorf("%s: got %v, want %v", name, xy2, xy)
+			}
+		}
+	}
+}
+
+func TestNewProjectionDefaults(t *testing.T) {
+	sph := WGS1984()
+	prj, err := NewProjection("tmerc", sph, map[string]float64{"lon0": 9})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]float64{"lat0": 0, "lon0": 9, "k0": 1, "x0": 0, "y0": 0}
+	for k, v := range prj.Params() {
+		if want[k] != v {
+			t.Errorf("Params: got %v", prj.Params())
+		}
+	}
+	params, ok := ProjectionParams("aea")
+	if !ok || len(params) != 4 || !params[0].Required || params[2].Required {
+		t.Errorf("ProjectionParams: got %v, %v", params, ok)
+	}
+}
+
+func TestNewProjectionErrors(t *testing.T) {
+	sph := WGS1984()
+	tests := []struct {
+		name string
+		par  map[string]float64
+		err  error
+		bad  string
+	}{
+		{"foo", nil, ErrUnknownProjection, "foo"},
+		{"aea", map[string]float64{"lat1": 30, "lat2": 40, "x0": 1}, ErrUnknownParam, "x0"},
+		{"aea", map[string]float64{"lat1": 30}, ErrMissingParam, "lat2"},
+		{"aea", map[string]float64{"lat1": 30, "lat2": -30}, ErrInvalidParam, "lat2"},
+		{"aea", map[string]float64{"lat1": 90, "lat2": 90}, ErrInvalidParam, "lat2"},
+		{"aea", map[string]float64{"lat1": -90, "lat2": -90}, ErrInvalidParam, "lat2"},
+		{"aea", map[string]float64{"lat1": 45, "lat2": 45}, ErrInvalidParam, "lat2"},
+		{"lcc", map[string]float64{"lat1": 90, "lat2": 45}, ErrInvalidParam, "lat1"},
+		{"lcc", map[string]float64{"lat1": -30, "lat2": -40, "lat0": 90}, ErrInvalidParam, "lat0"},
+		{"lcc1sp", map[string]float64{"lat0": 0}, ErrInvalidParam, "lat0"},
+		{"merc", map[string]float64{"lat1": 90}, ErrInvalidParam, "lat1"},
+		{"stere_b", map[string]float64{"lat1": 0}, ErrInvalidParam, "lat1"},
+		{"sterea", map[string]float64{"lat0": -90}, ErrInvalidParam, "lat0"},
+		{"sterea", map[string]float64{"lat0": 52, "k0": 0}, ErrInvalidParam, "k0"},
+		{"tmerc", map[string]float64{"lat0": 91}, ErrInvalidParam, "lat0"},
+		{"tmerc", map[string]float64{"k0": math.NaN()}, ErrInvalidParam, "k0"},
+		{"tmerc", map[string]float64{"k0": -1}, ErrInvalidParam, "k0"},
+		{"utm", map[string]float64{"zone": 61}, ErrInvalidParam, "zone"},
+		{"utm", map[string]float64{"zone": 33, "north": 0.5}, ErrInvalidParam, "north"},
+		{"utm", map[string]float64{"zone": 33, "lon0": 9}, ErrInvalidParam, "lon0"},
+		{"stere_a", map[string]float64{"lat0": 45}, ErrInvalidParam, "lat0"},
+		{"webmerc", map[string]float64{"lon0": 10}, ErrInvalidParam, "lon0"},
+	}
+	for _, tt := range tests {
+		_, err := NewProjection(tt.name, sph, tt.par)
+		if !errors.Is(err, tt.err) || !strings.Contains(err.Error(), "`"+tt.bad+"`") {
+			t.Errorf("%s %v: got %v", tt.name, tt.par, err)
+		}
+	}
+	if _, err := NewProjection("webmerc", GRS1980(), nil); !errors.Is(err, ErrInvalidParam) {
+		t.Errorf("webmerc GRS1980: got %v", err)
+	}
+	// the errors of the other factories are wrapped
+	errTest := errors.New("test")
+	RegisterProjection("test_error", nil, func(Spheroid, map[string]float64) (MapProjection, error) { return nil, errTest })
+	if _, err := NewProjection("test_error", sph, nil); !errors.Is(err, errTest) {
+		t.Errorf("test_error: got %v", err)
+	}
+}
+
+func TestRegisterProjection(t *testing.T) {
+	RegisterProjection("test_eqearth", []ProjParam{{Name: "lon0"}},
+		func(sph Spheroid, par map[string]float64) (MapProjection, error) {
+			return NewEqualEarth(sph, par["lon0"]), nil
+		})
+	prj, err := NewProjection("test_eqearth", WGS1984(), map[string]float64{"lon0": 45})
+	if err != nil || prj.Params()["lon0"] != 45 {
+		t.Errorf("NewProjection: got %v, %v", prj, err)
+	}
+	if _, err := ParsePROJ("+proj=test_eqearth +lon0=45"); err != nil {
+		t.Errorf("ParsePROJ: %v", err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("RegisterProjection: no panic on a duplicate name")
+		}
+	}()
+	RegisterProjection("aea", nil, func(Spheroid, map[string]float64) (MapProjection, error) { return nil, nil })
+}
@@ -0,0 +1,104 @@
+package geomys
+
+import (
+	"github.com/reconditematter/mym"
+	"math"
+)
+
+// Rhumb -- rhumb line (loxodrome) solver for a spheroidal model of the Earth.
+//
+// A rhumb line crosses all meridians at the same azimuth.
+// The distances are computed with divided differences of the meridian arc
+// and of the isometric latitude, so that the solutions stay accurate
+// for the rhumb lines running close to a parallel.
+type Rhumb struct {
+	sph      Spheroid
+	e, e2, A float64
+	C        [7]float64
+}
+
+// NewRhumb -- returns a rhumb line solver for the spheroid `sph`.
+func NewRhumb(sph Spheroid) Rhumb {
+	n := sph.Fpp()
+	e2 := sph.E2()
+	return Rhumb{sph: sph, e: math.Sqrt(e2), e2: e2, A: sph.A() * merA0f(n), C: merCf(n)}
+}
+
+// Spheroid -- returns the spheroid of `r`.
+func (r Rhumb) Spheroid() Spheroid {
+	return r.sph
+}
+
+// Inverse -- solves the inverse problem: given two points `p1` and `p2`, find
+// the rhumb line distance `s12` (meters) between the points, also find the constant
+// azimuth `α12` (degrees) of the rhumb line.
+func (r Rhumb) Inverse(p1, p2 Point) (s12 float64, α12 float64) {
+	lat1, lon1, _ := p1.Geo()
+	lat2, lon2, _ := p2.Geo()
+	φ1, φ2 := lat1*(math.Pi/180), lat2*(math.Pi/180)
+	//
+	if math.Abs(lat1) == 90 || math.Abs(lat2) == 90 {
+		// the rhumb line to/from a pole is a meridian
+		s12 = math.Abs(r.sph.MeridianDistance(lat2) - r.sph.MeridianDistance(lat1))
+		if lat2 < lat1 {
+			α12 = 180
+		}
+		return
+	}
+	//
+	dlon, _ := angDiff(lon1, lon2)
+	Δλ := dlon * (math.Pi / 180)
+	Δφ := (lat2 - lat1) * (math.Pi / 180)
+	Dm := r.dmeridarc(φ1, φ2)
+	Dψ := r.disolat(φ1, φ2)
+	s12 = math.Hypot(Dm*Δφ, (Dm/Dψ)*Δλ)
+	α12 = atan2d(Δλ, Dψ*Δφ)
+	return
+}
+
+// Direct -- solves the direct problem: given the source point `p1`, the constant azimuth
+// `α12` (degrees), and the rhumb line distance `s12` (meters), find the target point `p2`.
+// The rhumb line ends at a pole, so `ok` is false when the rhumb line reaches a pole
+// before the distance `s12`, or when `α12`,`s12` are not finite.
+func (r Rhumb) Direct(p1 Point, α12 float64, s12 float64) (p2 Point, ok bool) {
+	lat1, lon1, _ := p1.Geo()
+	φ1 := lat1 * (math.Pi / 180)
+	sα, cα := mym.SinCosD(α12)
+	//
+	m2 := r.sph.MeridianDistance(lat1) + s12*cα
+	if !(math.Abs(m2) <= r.sph.QuarterMeridian()*(1+mym.Epsilon)) || math.IsInf(s12, 0) {
+		return Point{}, false
+	}
+	lat2 := r.sph.MeridianLat(m2)
+	φ2 := lat2 * (math.Pi / 180)
+	//
+	var Δλ float64
+	if math.Abs(lat1) < 90 && math.Abs(lat2) < 90 {
+		Δλ = s12 * sα * r.disolat(φ1, φ2) / r.dmeridarc(φ1, φ2)
+	}
+	lon2 := angNormalize(lon1 + Δλ*(180/math.Pi))
+	return Geo(lat2, lon2, 0.0), true
+}
+
+// dmeridarc -- computes the divided difference of the meridian arc length
+// between the latitudes `φ1` and `φ2` (radians).
+func (r Rhumb) dmeridarc(φ1, φ2 float64) float64 {
+	Δφ, Σφ := φ2-φ1, φ2+φ1
+	d := 1.0
+	for j := 1; j < len(r.C); j++ {
+		J := float64(j)
+		d += 2 * J * r.C[j] * math.Cos(J*Σφ) * sinc(J*Δφ)
+	}
+	return r.A * d
+}
+
+// disolat -- computes the divided difference of the isometric latitude
+// ψ(φ)=asinh(tan(φ))-e⋅atanh(e⋅sin(φ)) between the latitudes `φ1` and `φ2` (radians).
+func (r Rhumb) disolat(φ1, φ2 float64) float64 {
+	s1, c1 := math.Sincos(φ1)
+	s2, c2 := math.Sincos(φ2)
+	Δφ := φ2 - φ1
+	dtan := sinc(Δφ) / (c1 * c2)
+	dsin := math.Cos((φ1+φ2)/2) * sinc(Δφ/2)
+	return dasinh(s1/c1, s2/c2)*dtan - r.e2*datanh(r.e*s1, r.e*s2)*dsin
+}
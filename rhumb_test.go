@@ -0,0 +1,91 @@
+package geomys
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRhumbInverse(t *testing.T) {
+	r := NewRhumb(WGS1984())
+	cases := []struct {
+		name                   string
+		lat1, lon1, lat2, lon2 float64
+		s12, α12, tol          float64
+	}{
+		// RhumbSolve -i: 40.6 -73.8 51.6 -0.5
+		{"JFK-LHR", 40.6, -73.8, 51.6, -0.5, 5771083.383, 77.76838971, 0.5e-3},
+		{"meridian", 10, 20, 70, 20, 6663125.894536, 0, 1e-6},
+		{"east-west", -30, 170, -30.001, -170, 1929715.9343, 90.003291353653, 1e-3},
+		{"parallel", 45, 0, 45, 10, 788468.350940, 90, 1e-6},
+		{"pole", 0, 0, 90, 0, 10001965.729313, 0, 1e-6},
+		{"pole", 0, 50, -90, 0, 10001965.729313, 180, 1e-6},
+		{"long", -50, 30, 60, -120, 18401891.853955, -48.493940996476, 1e-6},
+	}
+	for _, c := range cases {
+		s12, α12 := r.Inverse(Geo(c.lat1, c.lon1, 0), Geo(c.lat2, c.lon2, 0))
+		if math.Abs(s12-c.s12) > c.tol || math.Abs(α12-c.α12) > 1e-8 {
+			t.Errorf("%s: got %v %v, want %v %v", c.name, s12, α12, c.s12, c.α12)
+		}
+	}
+}
+
+func TestRhumbDirect(t *testing.T) {
+	sph := WGS1984()
+	r := NewRhumb(sph)
+	// RhumbSolve: 40.6 -73.8 77.76838971 5771083.383
+	p2, ok := r.Direct(Geo(40.6, -73.8, 0), 77.76838971, 5771083.383)
+	if lat, lon, _ := p2.Geo(); !ok || math.Abs(lat-51.6) > 1e-8 || math.Abs(lon+0.5) > 1e-8 {
+		t.Errorf("JFK-LHR: got (%v,%v),%v", lat, lon, ok)
+	}
+	p2, ok = r.Direct(Geo(45, 10, 0), 89.9, 5e6)
+	if lat, lon, _ := p2.Geo(); !ok || math.Abs(lat-45.07852462889) > 1e-11 || math.Abs(lon-73.457358165) > 1e-8 {
+		t.Errorf("east-west: got (%v,%v),%v", lat, lon, ok)
+	}
+	// the end point at a pole, the meridian distance from 10° to 90° is 8896110.896078 m
+	s := 8896110.896078
+	p2, ok = r.Direct(Geo(10, 0, 0), 0, s-1e-6)
+	if lat, _, _ := p2.Geo(); !ok || math.Abs(lat-90) > 1e-10 {
+		t.Errorf("pole: got %v,%v", lat, ok)
+	}
+	// beyond a pole
+	for _, c := range [][3]float64{{10, 0, s + 1}, {30, 30, 1e7}, {-10, 150, 2e7}, {0, 0, math.Inf(1)}, {0, math.NaN(), 1}} {
+		if p2, ok := r.Direct(Geo(c[0], 0, 0), c[1], c[2]); ok {
+			t.Errorf("Direct %v: got %v", c, p2)
+		}
+	}
+	// the round trips
+	for lat1 := -80.0; lat1 <= 80; lat1 += 20 {
+		for α := -180.0; α < 180; α += 22.5 {
+			p1 := Geo(lat1, 170, 0)
+			p2, ok := r.Direct(p1, α, 2e6)
+			// the rhumb lines to the poles end there
+			_, cα := math.Sincos(α * (math.Pi / 180))
+			dn, _ := r.Inverse(p1, Geo(90, 0, 0))
+			ds, _ := r.Inverse(p1, Geo(-90, 0, 0))
+			if want := -ds <= 2e6*cα && 2e6*cα <= dn; ok != want {
+				t.Errorf("(%v,%v): got %v", lat1, α, ok)
+			}
+			if !ok {
+				continue
+			}
+			s12, α12 := r.Inverse(p1, p2)
+			if math.Abs(s12-2e6) > 1e-6 || math.Abs(α12-α) > 1e-9 && math.Abs(α12-α) < 360-1e-9 {
+				t.Errorf("(%v,%v): got %v %v", lat1, α, s12, α12)
+			}
+		}
+	}
+}
+
+func TestGeoMatrixRhumb(t *testing.T) {
+	sph := WGS1984()
+	r := NewRhumb(sph)
+	p := []Point{Geo(0, 0, 0), Geo(90, 0, 0), Geo(-33.9, 151.2, 0), Geo(51.5, -0.1, 0), Geo(40.6, -73.8, 0)}
+	M := GeoMatrix(sph, p, DistRhumb)
+	for i := range p {
+		for j := i + 1; j < len(p); j++ {
+			if s12, _ := r.Inverse(p[i], p[j]); M.Get(i, j) != s12 || M.Get(j, i) != s12 {
+				t.Errorf("GeoMatrix(%v,%v): got %v, want %v", i, j, M.Get(i, j), s12)
+			}
+		}
+	}
+}
@@ -0,0 +1,350 @@
+package geomys
+
+import (
+	"fmt"
+	"github.com/reconditematter/mym"
+	"math"
+)
+
+// PolarStereographic -- polar stereographic map projection.
+//
+// The projection is defined in one of the three variants:
+//
+//	A -- the scale factor at the pole is given;
+//	B -- the latitude of true scale is given;
+//	C -- the latitude of true scale is given, the false origin is on this latitude.
+//
+// Reference: IOGP Publication 373-7-2, Geomatics Guidance Note number 7, part 2.
+// Coordinate Conversions and Transformations including Formulas (2019).
+type PolarStereographic struct {
+	sph   Spheroid
+	par   map[string]float64
+	north bool
+	ρ1    float64 // ρ = ρ1⋅tan(π/4-χ/2), where χ is the conformal latitude
+	ρF    float64 // the offset of the false origin from the pole
+}
+
+// NewPolarStereographicA -- returns a new polar stereographic map projection (variant A)
+// based on the spheroid `sph`. This function causes a runtime panic when lat0∉{-90,90},
+// lon0∉[-180,180], or k0≤0.
+//
+// The projection has the following parameters:
+//
+//	lat0 -- latitude of the natural origin, that is the pole
+//	lon0 -- longitude of the natural origin
+//	k0   -- scale factor at the natural origin
+//	x0   -- false easting
+//	y0   -- false northing
+func NewPolarStereographicA(sph Spheroid, lat0, lon0, k0, x0, y0 float64) PolarStereographic {
+	if !(math.Abs(lat0) == 90) {
+		panic("geomys.NewPolarStereographicA: domain error: `lat0`")
+	}
+	if !(-180 <= lon0 && lon0 <= 180) {
+		panic("geomys.NewPolarStereographicA: domain error: `lon0`")
+	}
+	if !(k0 > 0 && k0 < math.Inf(1)) {
+		panic("geomys.NewPolarStereographicA: domain error: `k0`")
+	}
+	par := map[string]float64{"lat0": lat0, "lon0": lon0, "k0": k0, "x0": x0, "y0": y0}
+	return PolarStereographic{sph: sph, par: par, north: lat0 > 0, ρ1: k0 * pstρ1(sph)}
+}
+
+// NewPolarStereographicB -- returns a new polar stereographic map projection (variant B)
+// based on the spheroid `sph`. The pole of the projection is in the hemisphere of `lat1`.
+// This function causes a runtime panic when lat1∉[-90,90], lat1=0, or lon0∉[-180,180].
+//
+// The projection has the following parameters:
+//
+//	lat1 -- latitude of true scale
+//	lon0 -- longitude of the origin
+//	x0   -- false easting
+//	y0   -- false northing
+func NewPolarStereographicB(sph Spheroid, lat1, lon0, x0, y0 float64) PolarStereographic {
+	prj := newpst("geomys.NewPolarStereographicB", sph, lat1, lon0)
+	prj.par["x0"], prj.par["y0"] = x0, y0
+	return prj
+}
+
+// NewPolarStereographicC -- returns a new polar stereographic map projection (variant C)
+// based on the spheroid `sph`. The pole of the projection is in the hemisphere of `lat1`.
+// This function causes a runtime panic when lat1∉[-90,90], lat1=0, or lon0∉[-180,180].
+//
+// The projection has the following parameters:
+//
+//	lat1 -- latitude of true scale
+//	lon0 -- longitude of the false origin
+//	x0   -- easting at the false origin
+//	y0   -- northing at the false origin
+func NewPolarStereographicC(sph Spheroid, lat1, lon0, x0, y0 float64) PolarStereographic {
+	prj := newpst("geomys.NewPolarStereographicC", sph, lat1, lon0)
+	prj.par["x0"], prj.par["y0"] = x0, y0
+	prj.ρF = prj.rhopst(math.Abs(lat1))
+	return prj
+}
+
+// Spheroid -- returns the spheroid of the map projection.
+func (prj PolarStereographic) Spheroid() Spheroid {
+	if prj.par == nil {
+		panic("geomys.PolarStereographic.Spheroid: uninitialized structure")
+	}
+	//
+	return prj.sph
+}
+
+// Params -- returns the parameters of the map projection.
+func (prj PolarStereographic) Params() map[string]float64 {
+	if prj.par == nil {
+		panic("geomys.PolarStereographic.Params: uninitialized structure")
+	}
+	//
+	par := make(map[string]float64)
+	for k, v := range prj.par {
+		par[k] = v
+	}
+	return par
+}
+
+// Project -- transforms a geographic point from
+// the spheroid into a location on the plane.
+func (prj PolarStereographic) Project(p Point) (xy [2]float64) {
+	xy, _, _ = prj.ProjectExt(p)
+	return
+}
+
+// ProjectExt -- transforms a geographic point from the spheroid into a location on the plane,
+// also returns the meridian convergence `γ` (degrees), that is the bearing of grid north
+// clockwise from true north, and the point scale `k`. The opposite pole has k=+∞.
+func (prj PolarStereographic) ProjectExt(p Point) (xy [2]float64, γ, k float64) {
+	if prj.par == nil {
+		panic("geomys.PolarStereographic.ProjectExt: uninitialized structure")
+	}
+	//
+	lat, lon, _ := p.Geo()
+	if !prj.north {
+		lat = -lat
+	}
+	ρ := prj.rhopst(lat)
+	θ := angNormalize(lon - prj.par["lon0"])
+	sinθ, cosθ := mym.SinCosD(θ)
+	xy[0] = prj.par["x0"] + ρ*sinθ
+	if prj.north {
+		xy[1] = prj.par["y0"] + prj.ρF - ρ*cosθ
+		γ = θ
+	} else {
+		xy[1] = prj.par["y0"] - prj.ρF + ρ*cosθ
+		γ = -θ
+	}
+	// k = ρ/(N(φ)⋅cos(φ)), at the pole k = ρ1/ρ1(k0=1)
+	_, cosφ := mym.SinCosD(lat)
+	switch {
+	case lat == 90:
+		k = prj.ρ1 / pstρ1(prj.sph)
+	case lat == -90:
+		k = math.Inf(1)
+	default:
+		k = ρ / (prj.sph.PrimeVerticalRadius(lat) * cosφ)
+	}
+	return
+}
+
+// Unproject -- transforms a location on the plane into
+// a geographic point on the spheroid. Returns an error wrapping
+// ErrOutOfDomain when `xy` is outside the image of the spheroid.
+func (prj PolarStereographic) Unproject(xy [2]float64) (Point, error) {
+	if prj.par == nil {
+		panic("geomys.PolarStereographic.Unproject: uninitialized structure")
+	}
+	//
+	dx := xy[0] - prj.par["x0"]
+	dy := xy[1] - prj.par["y0"]
+	if prj.north {
+		dy = prj.ρF - dy
+	} else {
+		dy = prj.ρF + dy
+	}
+	if !(mym.FiniteIs(dx) && mym.FiniteIs(dy)) {
+		return Point{}, fmt.Errorf("geomys.PolarStereographic.Unproject: %w: `xy`", ErrOutOfDomain)
+	}
+	// tan(π/4-χ/2) = ρ/ρ1
+	t := math.Hypot(dx, dy) / prj.ρ1
+	χ := 90 - 2*math.Atan(t)*(180/math.Pi)
+	lat := prj.sph.GeoLat(ConformalLat, χ)
+	λ := atan2d(dx, dy)
+	if !prj.north {
+		lat = -lat
+	}
+	return Geo(lat, angNormalize(prj.par["lon0"]+λ), 0.0), nil
+}
+
+// rhopst -- returns the distance on the plane from the pole
+// to the parallel `lat` (degrees) in the hemisphere of the pole.
+func (prj PolarStereographic) rhopst(lat float64) float64 {
+	// tan(π/4-χ/2) = cos(χ)/(1+sin(χ))
+	sχ, cχ := mym.SinCosD(prj.sph.AuxLat(ConformalLat, lat))
+	return prj.ρ1 * cχ / (1 + sχ)
+}
+
+func newpst(fn string, sph Spheroid, lat1, lon0 float64) PolarStereographic {
+	if !(-90 <= lat1 && lat1 <= 90) || lat1 == 0 {
+		panic(fn + ": domain error: `lat1`")
+	}
+	if !(-180 <= lon0 && lon0 <= 180) {
+		panic(fn + ": domain error: `lon0`")
+	}
+	par := map[string]float64{"lat1": lat1, "lon0": lon0}
+	prj := PolarStereographic{sph: sph, par: par, north: lat1 > 0}
+	φc := math.Abs(lat1)
+	if φc == 90 {
+		prj.ρ1 = pstρ1(sph)
+		return prj
+	}
+	// the scale is true on the parallel φc: ρ(φc) = N(φc)⋅cos(φc)
+	_, cosφc := mym.SinCosD(φc)
+	sχc, cχc := mym.SinCosD(sph.AuxLat(ConformalLat, φc))
+	prj.ρ1 = sph.PrimeVerticalRadius(φc) * cosφc * (1 + sχc) / cχc
+	return prj
+}
+
+// pstρ1 -- returns ρ1 of the polar stereographic projection with the unit scale at the pole:
+//
+//	ρ1 = 2⋅a/√((1+e)^(1+e)⋅(1-e)^(1-e)).
+func pstρ1(sph Spheroid) float64 {
+	e2 := sph.E2()
+	e := math.Sqrt(e2)
+	return 2 * sph.A() / (math.Sqrt(1-e2) * math.Exp(e*math.Atanh(e)))
+}
+
+// ObliqueStereographic -- oblique stereographic map projection on the spheroid.
+// The spheroid is conformally mapped onto the Gaussian sphere, which is then
+// projected stereographically (the double projection).
+//
+// Reference: IOGP Publication 373-7-2, Geomatics Guidance Note number 7, part 2.
+// Coordinate Conversions and Transformations including Formulas (2019).
+type ObliqueStereographic struct {
+	sph       Spheroid
+	par       map[string]float64
+	n, κ, k2R float64
+	sχ0, cχ0  float64
+}
+
+// NewObliqueStereographic -- returns a new oblique stereographic map projection
+// based on the spheroid `sph`. This function causes a runtime panic when lat0∉(-90,90),
+// lon0∉[-180,180], or k0≤0.
+//
+// The projection has the following parameters:
+//
+//	lat0 -- latitude of the natural origin
+//	lon0 -- longitude of the natural origin
+//	k0   -- scale factor at the natural origin
+//	x0   -- false easting
+//	y0   -- false northing
+func NewObliqueStereographic(sph Spheroid, lat0, lon0, k0, x0, y0 float64) ObliqueStereographic {
+	if !(-90 < lat0 && lat0 < 90) {
+		panic("geomys.NewObliqueStereographic: domain error: `lat0`")
+	}
+	if !(-180 <= lon0 && lon0 <= 180) {
+		panic("geomys.NewObliqueStereographic: domain error: `lon0`")
+	}
+	if !(k0 > 0 && k0 < math.Inf(1)) {
+		panic("geomys.NewObliqueStereographic: domain error: `k0`")
+	}
+	par := map[string]float64{"lat0": lat0, "lon0": lon0, "k0": k0, "x0": x0, "y0": y0}
+	prj := ObliqueStereographic{sph: sph, par: par}
+	prj.iniost()
+	return prj
+}
+
+// Spheroid -- returns the spheroid of the map projection.
+func (prj ObliqueStereographic) Spheroid() Spheroid {
+	if prj.par == nil {
+		panic("geomys.ObliqueStereographic.Spheroid: uninitialized structure")
+	}
+	//
+	return prj.sph
+}
+
+// Params -- returns the parameters of the map projection.
+func (prj ObliqueStereographic) Params() map[string]float64 {
+	if prj.par == nil {
+		panic("geomys.ObliqueStereographic.Params: uninitialized structure")
+	}
+	//
+	par := make(map[string]float64)
+	for k, v := range prj.par {
+		par[k] = v
+	}
+	return par
+}
+
+// Project -- transforms a geographic point from
+// the spheroid into a location on the plane.
+func (prj ObliqueStereographic) Project(p Point) (xy [2]float64) {
+	if prj.par == nil {
+		panic("geomys.ObliqueStereographic.Project: uninitialized structure")
+	}
+	//
+	lat, lon, _ := p.Geo()
+	// the latitude χ and the longitude Λ on the Gaussian sphere
+	sχ, cχ := prj.gaussost(lat)
+	sΛ, cΛ := mym.SinCosD(prj.n * angNormalize(lon-prj.par["lon0"]))
+	B := 1 + sχ*prj.sχ0 + cχ*prj.cχ0*cΛ
+	xy[0] = prj.par["x0"] + prj.k2R*cχ*sΛ/B
+	xy[1] = prj.par["y0"] + prj.k2R*(sχ*prj.cχ0-cχ*prj.sχ0*cΛ)/B
+	return
+}
+
+// Unproject -- transforms a location on the plane into
+// a geographic point on the spheroid. Returns an error wrapping
+// ErrOutOfDomain when `xy` is outside the image of the spheroid.
+func (prj ObliqueStereographic) Unproject(xy [2]float64) (Point, error) {
+	if prj.par == nil {
+		panic("geomys.ObliqueStereographic.Unproject: uninitialized structure")
+	}
+	//
+	x := (xy[0] - prj.par["x0"]) / prj.k2R
+	y := (xy[1] - prj.par["y0"]) / prj.k2R
+	if !(mym.FiniteIs(x) && mym.FiniteIs(y)) {
+		return Point{}, fmt.Errorf("geomys.ObliqueStereographic.Unproject: %w: `xy`", ErrOutOfDomain)
+	}
+	// the inverse of the stereographic projection of the Gaussian sphere,
+	// c is the angular distance from the origin
+	ρ := math.Hypot(x, y)
+	sc, cc := 2*ρ/(1+ρ*ρ), (1-ρ*ρ)/(1+ρ*ρ)
+	sχ := cc * prj.sχ0
+	if ρ > 0 {
+		sχ += y * sc * prj.cχ0 / ρ
+	}
+	Λ := math.Atan2(x*sc, ρ*prj.cχ0*cc-y*prj.sχ0*sc) * (180 / math.Pi)
+	λ := Λ / prj.n
+	// ψ = (atanh(sin(χ)) - κ)/n, where ψ is the isometric latitude
+	ψ := (math.Atanh(math.Max(-1, math.Min(1, sχ))) - prj.κ) / prj.n * (180 / math.Pi)
+	lat := prj.sph.GeoLatIsometric(ψ)
+	return Geo(lat, angNormalize(prj.par["lon0"]+λ), 0.0), nil
+}
+
+func (prj *ObliqueStereographic) iniost() {
+	lat0 := prj.par["lat0"]
+	k0 := prj.par["k0"]
+	e2 := prj.sph.E2()
+	//
+	sinφ0, cosφ0 := mym.SinCosD(lat0)
+	// the radius of the Gaussian sphere R = √(M⋅N)
+	R := prj.sph.GaussianRadius(lat0)
+	n := math.Sqrt(1 + e2*mym.Sq(cosφ0*cosφ0)/(1-e2))
+	// the isometric latitude ψ is mapped to n⋅ψ+κ on the Gaussian sphere
+	ψ0 := prj.sph.IsometricLat(lat0) * (math.Pi / 180)
+	sχ1 := math.Tanh(n * ψ0)
+	c := (n + sinφ0) * (1 - sχ1) / ((n - sinφ0) * (1 + sχ1))
+	prj.n, prj.κ, prj.k2R = n, math.Log(c)/2, 2*k0*R
+	prj.sχ0, prj.cχ0 = prj.gaussost(lat0)
+}
+
+// gaussost -- returns sin(χ) and cos(χ), where χ is the latitude
+// on the Gaussian sphere of the geographic latitude `lat` (degrees).
+func (prj ObliqueStereographic) gaussost(lat float64) (sχ, cχ float64) {
+	if math.Abs(lat) == 90 {
+		return math.Copysign(1, lat), 0
+	}
+	ψ := prj.n*prj.sph.IsometricLat(lat)*(math.Pi/180) + prj.κ
+	return math.Tanh(ψ), 1 / math.Cosh(ψ)
+}
@@ -0,0 +1,80 @@
+package geomys
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestStereographicKnown(t *testing.T) {
+	// IOGP Guidance Note 7-2
+	dms := func(d, m, s float64) float64 {
+		return math.Copysign(math.Abs(d)+m/60+s/3600, d)
+	}
+	cases := []struct {
+		name string
+		prj  InvertibleProjection
+		p    Point
+		xy   [2]float64
+	}{
+		{"A", NewPolarStereographicA(WGS1984(), 90, 0, 0.994, 2e6, 2e6),
+			Geo(73, 44, 0), [2]float64{3320416.75, 632668.43}},
+		{"B", NewPolarStereographicB(WGS1984(), -71, 70, 6e6, 6e6),
+			Geo(-75, 120, 0), [2]float64{7255380.79, 7053389.56}},
+		{"C", NewPolarStereographicC(International1924(), -67, 140, 300000, 200000),
+			Geo(dms(-66, 36, 18.820), dms(140, 4, 17.040), 0), [2]float64{303169.52, 244055.72}},
+		{"RD", NewObliqueStereographic(NewSpheroid(6377397.155, 1/299.1528128),
+			dms(52, 9, 22.178), dms(5, 23, 15.500), 0.9999079, 155000, 463000),
+			Geo(53, 6, 0), [2]float64{196105.283, 557057.739}},
+	}
+	for _, c := range cases {
+		xy := c.prj.Project(c.p)
+		if math.Abs(xy[0]-c.xy[0]) > 0.01 || math.Abs(xy[1]-c.xy[1]) > 0.01 {
+			t.Errorf("%s: Project got %v, want %v", c.name, xy, c.xy)
+		}
+		p, err := c.prj.Unproject(c.xy)
+		if err != nil {
+			t.Fatalf("%s: Unproject: %v", c.name, err)
+		}
+		lat, lon, _ := p.Geo()
+		lat0, lon0, _ := c.p.Geo()
+		if math.Abs(lat-lat0) > 1e-7 || math.Abs(lon-lon0) > 1e-7 {
+			t.Errorf("%s: Unproject got (%v,%v)", c.name, lat, lon)
+		}
+	}
+}
+
+func TestStereographicRoundTrip(t *testing.T) {
+	sph := WGS1984()
+	prjs := []InvertibleProjection{
+		NewPolarStereographicA(sph, -90, 30, 0.97, 0, 0),
+		NewPolarStereographicB(sph, 90, -45, 0, 0),
+		NewPolarStereographicB(sph, 70, -45, 0, 0),
+		NewPolarStereographicC(sph, -71, 0, 1e6, 1e6),
+		NewObliqueStereographic(sph, 46.95, 7.44, 1, 6e5, 2e5),
+		NewObliqueStereographic(sph, -40, 175, 0.9999, 0, 0),
+	}
+	for _, prj := range prjs {
+		lat0 := prj.Params()["lat0"] + prj.Params()["lat1"]
+		for lat := -90.0; lat <= 90; lat += 2.5 {
+			for lon := -180.0; lon <= 180; lon += 10 {
+				if math.Abs(lat-lat0) > 80 {
+					// far from the origin the projection is ill-conditioned
+					continue
+				}
+				p, err := prj.Unproject(prj.Project(Geo(lat, lon, 0)))
+				if err != nil {
+					t.Fatalf("%v (%v,%v): %v", prj.Params(), lat, lon, err)
+				}
+				lat2, lon2, _ := p.Geo()
+				dlon, _ := angDiff(lon, lon2)
+				if math.Abs(lat2-lat) > 1e-9 || math.Abs(lat) < 90 && math.Abs(dlon) > 1e-9 {
+					t.Errorf("%v (%v,%v): got (%v,%v)", prj.Params(), lat, lon, lat2, lon2)
+				}
+			}
+		}
+		if _, err := prj.Unproject([2]float64{math.NaN(), 0}); !errors.Is(err, ErrOutOfDomain) {
+			t.Errorf("%v: got %v", prj.Params(), err)
+		}
+	}
+}
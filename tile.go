@@ -0,0 +1,163 @@
+package geomys
+
+import (
+	"github.com/reconditematter/mym"
+	"math"
+	"strings"
+)
+
+// TileMaxLat -- the maximal latitude (degrees) covered by the Web Mercator tiles, atan(sinh(π)).
+const TileMaxLat = 85.05112877980659
+
+// TileMaxZoom -- the maximal zoom level of the tiles.
+const TileMaxZoom = 30
+
+// Tile -- represents an XYZ (slippy map) tile of the Web Mercator projection,
+// X increases eastward from the antimeridian and Y increases southward from TileMaxLat.
+type Tile struct {
+	X, Y, Z int
+}
+
+// TileAt -- returns the tile at the zoom level `z` containing `p`.
+// The latitude of `p` is clamped to [-TileMaxLat,TileMaxLat].
+// This function causes a runtime panic when z∉[0,TileMaxZoom].
+func TileAt(p Point, z int) Tile {
+	if !(0 <= z && z <= TileMaxZoom) {
+		panic("geomys.TileAt: domain error: `z`")
+	}
+	lat, lon, _ := p.Geo()
+	lat = math.Max(-TileMaxLat, math.Min(TileMaxLat, lat))
+	x, y := tileXY(lat, lon)
+	return Tile{tileIndex(x, z), tileIndex(y, z), z}
+}
+
+// Bounds -- returns the south-west and north-east corners of `t`.
+func (t Tile) Bounds() (sw, ne Point) {
+	t.check("Bounds")
+	n := math.Ldexp(1, t.Z)
+	lonw := float64(t.X)/n*360 - 180
+	lone := float64(t.X+1)/n*360 - 180
+	latn := math.Atan(math.Sinh(math.Pi*(1-2*float64(t.Y)/n))) * (180 / math.Pi)
+	lats := math.Atan(math.Sinh(math.Pi*(1-2*float64(t.Y+1)/n))) * (180 / math.Pi)
+	return Geo(lats, lonw, 0.0), Geo(latn, lone, 0.0)
+}
+
+// BoundsXY -- returns the minimal and maximal Web Mercator coordinates (meters) of `t`.
+func (t Tile) BoundsXY() (lo, hi [2]float64) {
+	t.check("BoundsXY")
+	c := math.Pi * WGS1984().A()
+	w := 2 * c / math.Ldexp(1, t.Z)
+	lo[0] = -c + float64(t.X)*w
+	hi[0] = lo[0] + w
+	hi[1] = c - float64(t.Y)*w
+	lo[1] = hi[1] - w
+	return
+}
+
+// QuadKey -- returns the quadkey of `t`, that is a string of `t.Z` digits 0,...,3
+// interleaving the bits of the tile coordinates (the quadkey of the zoom level 0 is empty).
+//
+// See: https://learn.microsoft.com/en-us/bingmaps/articles/bing-maps-tile-system
+func (t Tile) QuadKey() string {
+	t.check("QuadKey")
+	var B strings.Builder
+	for i := t.Z; i > 0; i-- {
+		m := 1 << (i - 1)
+		d := byte('0')
+		if t.X&m != 0 {
+			d++
+		}
+		if t.Y&m != 0 {
+			d += 2
+		}
+		B.WriteByte(d)
+	}
+	return B.String()
+}
+
+// QuadKeyTile -- decodes the quadkey `key` into a tile. When `key` is decoded
+// without errors, sets `ok` to true; otherwise sets `ok` to false and returns
+// the zero tile. The length of `key` must not exceed TileMaxZoom.
+func QuadKeyTile(key string) (t Tile, ok bool) {
+	if len(key) > TileMaxZoom {
+		return Tile{}, false
+	}
+	for i := 0; i < len(key); i++ {
+		d := key[i]
+		if !('0' <= d && d <= '3') {
+			return Tile{}, false
+		}
+		d -= '0'
+		t.X = t.X<<1 | int(d&1)
+		t.Y = t.Y<<1 | int(d>>1)
+	}
+	t.Z = len(key)
+	return t, true
+}
+
+// TilesCovering -- returns the tiles at the zoom level `z` covering the bounding box
+// with the south-west corner `sw` and the north-east corner `ne`. The box crosses
+// the antimeridian when the longitude of `sw` is greater than the longitude of `ne`.
+// The tiles are ordered by rows from north to south, and by columns from west to east.
+// This function causes a runtime panic when z∉[0,TileMaxZoom]
+// or the latitude of `sw` is greater than the latitude of `ne`.
+func TilesCovering(sw, ne Point, z int) []Tile {
+	if !(0 <= z && z <= TileMaxZoom) {
+		panic("geomys.TilesCovering: domain error: `z`")
+	}
+	lats, lonw, _ := sw.Geo()
+	latn, lone, _ := ne.Geo()
+	if lats > latn {
+		panic("geomys.TilesCovering: domain error: `sw`")
+	}
+	//
+	tsw := TileAt(sw, z)
+	tne := TileAt(ne, z)
+	n := 1 << z
+	nx := tne.X - tsw.X + 1
+	if lonw > lone {
+		nx += n
+	}
+	if nx > n {
+		nx = n
+	}
+	tiles := make([]Tile, 0, nx*(tsw.Y-tne.Y+1))
+	for y := tne.Y; y <= tsw.Y; y++ {
+		for i := 0; i < nx; i++ {
+			tiles = append(tiles, Tile{(tsw.X + i) % n, y, z})
+		}
+	}
+	return tiles
+}
+
+func (t Tile) check(fn string) {
+	if !(0 <= t.Z && t.Z <= TileMaxZoom) {
+		panic("geomys.Tile." + fn + ": domain error: `Z`")
+	}
+	n := 1 << t.Z
+	if !(0 <= t.X && t.X < n && 0 <= t.Y && t.Y < n) {
+		panic("geomys.Tile." + fn + ": domain error: `X,Y`")
+	}
+}
+
+// tileXY -- computes the normalized Web Mercator coordinates x,y∈[0,1]
+// of the latitude `lat` and the longitude `lon` (degrees).
+func tileXY(lat, lon float64) (x, y float64) {
+	sinφ, cosφ := mym.SinCosD(lat)
+	x = (lon + 180) / 360
+	y = (1 - math.Asinh(sinφ/cosφ)/math.Pi) / 2
+	return
+}
+
+// tileIndex -- returns the tile index of the normalized coordinate `x` at the zoom level `z`.
+func tileIndex(x float64, z int) int {
+	n := 1 << z
+	i := int(math.Floor(math.Ldexp(x, z)))
+	if i < 0 {
+		i = 0
+	}
+	if i >= n {
+		i = n - 1
+	}
+	return i
+}
@@ -0,0 +1,73 @@
+package geomys
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTileAt(t *testing.T) {
+	cases := []struct {
+		lat, lon float64
+		tile     Tile
+	}{
+		{51.5074, -0.1278, Tile{511, 340, 10}},
+		{0, 0, Tile{1, 1, 1}},
+		{90, -180, Tile{0, 0, 3}},
+		{-90, 180, Tile{7, 7, 3}},
+	}
+	for _, c := range cases {
+		if tile := TileAt(Geo(c.lat, c.lon, 0), c.tile.Z); tile != c.tile {
+			t.Errorf("TileAt(%v,%v): got %v, want %v", c.lat, c.lon, tile, c.tile)
+		}
+	}
+}
+
+func TestTileBounds(t *testing.T) {
+	web := NewWebMercator()
+	for _, tile := range []Tile{{0, 0, 0}, {3, 5, 3}, {511, 340, 10}, {12345, 23456, 15}} {
+		sw, ne := tile.Bounds()
+		lo, hi := tile.BoundsXY()
+		xysw, xyne := web.Project(sw), web.Project(ne)
+		for i := 0; i < 2; i++ {
+			if math.Abs(xysw[i]-lo[i]) > 1e-6 || math.Abs(xyne[i]-hi[i]) > 1e-6 {
+				t.Errorf("%v: got %v,%v and %v,%v", tile, xysw, xyne, lo, hi)
+			}
+		}
+		c, _ := web.Unproject([2]float64{(lo[0] + hi[0]) / 2, (lo[1] + hi[1]) / 2})
+		if TileAt(c, tile.Z) != tile {
+			t.Errorf("%v: center in %v", tile, TileAt(c, tile.Z))
+		}
+	}
+}
+
+func TestQuadKey(t *testing.T) {
+	// Bing Maps Tile System
+	if key := (Tile{3, 5, 3}).QuadKey(); key != "213" {
+		t.Errorf("QuadKey: got %v", key)
+	}
+	for _, tile := range []Tile{{0, 0, 0}, {3, 5, 3}, {511, 340, 10}, {1<<30 - 1, 12345, 30}} {
+		if t2, ok := QuadKeyTile(tile.QuadKey()); !ok || t2 != tile {
+			t.Errorf("QuadKeyTile(%v): got %v,%v", tile.QuadKey(), t2, ok)
+		}
+	}
+	for _, key := range []string{"0124", "x", "0000000000000000000000000000000"} {
+		if _, ok := QuadKeyTile(key); ok {
+			t.Errorf("QuadKeyTile(%v): ok", key)
+		}
+	}
+}
+
+func TestTilesCovering(t *testing.T) {
+	tiles := TilesCovering(Geo(-10, -10, 0), Geo(10, 10, 0), 2)
+	if len(tiles) != 4 || tiles[0] != (Tile{1, 1, 2}) || tiles[3] != (Tile{2, 2, 2}) {
+		t.Errorf("TilesCovering: got %v", tiles)
+	}
+	// across the antimeridian
+	tiles = TilesCovering(Geo(10, 170, 0), Geo(20, -170, 0), 3)
+	if len(tiles) != 2 || tiles[0] != (Tile{7, 3, 3}) || tiles[1] != (Tile{0, 3, 3}) {
+		t.Errorf("TilesCovering: got %v", tiles)
+	}
+	if tiles = TilesCovering(Geo(-90, -180, 0), Geo(90, 180, 0), 4); len(tiles) != 256 {
+		t.Errorf("TilesCovering: got %v tiles", len(tiles))
+	}
+}
@@ -0,0 +1,238 @@
+package geomys
+
+import (
+	"fmt"
+	"github.com/reconditematter/mym"
+	"math"
+	"math/cmplx"
+)
+
+// TransverseMercator -- transverse Mercator map projection.
+//
+// The projection uses the Krüger series of the 6th order in the third flattening n,
+// it is accurate to a few nanometers within 3900 km from the central meridian and
+// to a millimeter within 4000 km.
+//
+// Reference: Karney, C.F.F. Transverse Mercator with an accuracy of a few nanometers.
+// J Geodesy 85, 475–485 (2011).
+//
+// DOI: https://doi.org/10.1007/s00190-011-0445-3
+type TransverseMercator struct {
+	sph        Spheroid
+	par        map[string]float64
+	e, kA, kM0 float64
+	α, β       [7]float64
+}
+
+// NewTransverseMercator -- returns a new transverse Mercator map projection based on the spheroid `sph`.
+// This function causes a runtime panic when lat0∉[-90,90], lon0∉[-180,180], or k0≤0.
+//
+// The projection has the following parameters:
+//
+//	lat0 -- latitude of the origin
+//	lon0 -- longitude of the central meridian
+//	k0   -- scale factor on the central meridian
+//	x0   -- false easting
+//	y0   -- false northing
+func NewTransverseMercator(sph Spheroid, lat0, lon0, k0, x0, y0 float64) TransverseMercator {
+	if !(-90 <= lat0 && lat0 <= 90) {
+		panic("geomys.NewTransverseMercator: domain error: `lat0`")
+	}
+	if !(-180 <= lon0 && lon0 <= 180) {
+		panic("geomys.NewTransverseMercator: domain error: `lon0`")
+	}
+	if !(k0 > 0 && k0 < math.Inf(1)) {
+		panic("geomys.NewTransverseMercator: domain error: `k0`")
+	}
+	par := map[string]float64{"lat0": lat0, "lon0": lon0, "k0": k0, "x0": x0, "y0": y0}
+	prj := TransverseMercator{sph: sph, par: par}
+	prj.initm()
+	return prj
+}
+
+// Spheroid -- returns the spheroid of the map projection.
+func (prj TransverseMercator) Spheroid() Spheroid {
+	if prj.par == nil {
+		panic("geomys.TransverseMercator.Spheroid: uninitialized structure")
+	}
+	//
+	return prj.sph
+}
+
+// Params -- returns the parameters of the map projection.
+func (prj TransverseMercator) Params() map[string]float64 {
+	if prj.par == nil {
+		panic("geomys.TransverseMercator.Params: uninitialized structure")
+	}
+	//
+	par := make(map[string]float64)
+	for k, v := range prj.par {
+		par[k] = v
+	}
+	return par
+}
+
+// Project -- transforms a geographic point from
+// the spheroid into a location on the plane.
+func (prj TransverseMercator) Project(p Point) (xy [2]float64) {
+	xy, _, _ = prj.ProjectExt(p)
+	return
+}
+
+// ProjectExt -- transforms a geographic point from the spheroid into a location on the plane,
+// also returns the meridian convergence `γ` (degrees), that is the bearing of grid north
+// clockwise from true north, and the point scale `k`.
+func (prj TransverseMercator) ProjectExt(p Point) (xy [2]float64, γ, k float64) {
+	if prj.par == nil {
+		panic("geomys.TransverseMercator.ProjectExt: uninitialized structure")
+	}
+	//
+	lat, lon, _ := p.Geo()
+	var ζ complex128
+	ζ, γ, k = prj.fwdtm(lat, angNormalize(lon-prj.par["lon0"]))
+	xy[0] = prj.kA*imag(ζ) + prj.par["x0"]
+	xy[1] = prj.kA*real(ζ) - prj.kM0 + prj.par["y0"]
+	return
+}
+
+// Unproject -- transforms a location on the plane into
+// a geographic point on the spheroid. Returns an error wrapping
+// ErrOutOfDomain when `xy` is outside the image of the hemisphere
+// centered on the central meridian.
+func (prj TransverseMercator) Unproject(xy [2]float64) (Point, error) {
+	if prj.par == nil {
+		panic("geomys.TransverseMercator.Unproject: uninitialized structure")
+	}
+	//
+	ξ := (xy[1] - prj.par["y0"] + prj.kM0) / prj.kA
+	η := (xy[0] - prj.par["x0"]) / prj.kA
+	if !(mym.FiniteIs(ξ) && mym.FiniteIs(η)) {
+		return Point{}, fmt.Errorf("geomys.TransverseMercator.Unproject: %w: `xy`", ErrOutOfDomain)
+	}
+	// ζ' = ζ - ∑ β[j]⋅sin(2jζ)
+	ζ := complex(ξ, η)
+	ζp := ζ
+	for j := 1; j <= 6; j++ {
+		ζp -= complex(prj.β[j], 0) * cmplx.Sin(complex(float64(2*j), 0)*ζ)
+	}
+	ξp, ηp := real(ζp), imag(ζp)
+	if !(math.Abs(ξp) <= math.Pi/2) {
+		// allow for round-off at the poles
+		if !(math.Abs(ξp) <= math.Pi/2*(1+64*mym.Epsilon)) {
+			return Point{}, fmt.Errorf("geomys.TransverseMercator.Unproject: %w: `xy`", ErrOutOfDomain)
+		}
+		ξp = math.Copysign(math.Pi/2, ξp)
+	}
+	sξp, cξp := math.Sincos(ξp)
+	shηp := math.Sinh(ηp)
+	χ := math.Atan2(sξp, math.Hypot(shηp, cξp)) * (180 / math.Pi)
+	λ := math.Atan2(shηp, cξp) * (180 / math.Pi)
+	lat := prj.sph.GeoLat(ConformalLat, χ)
+	lon := angNormalize(prj.par["lon0"] + λ)
+	return Geo(lat, lon, 0.0), nil
+}
+
+// UnprojectExt -- transforms a location on the plane into a geographic point
+// on the spheroid as Unproject does, also returns the meridian convergence `γ` (degrees)
+// and the point scale `k` at the point.
+func (prj TransverseMercator) UnprojectExt(xy [2]float64) (p Point, γ, k float64, err error) {
+	p, err = prj.Unproject(xy)
+	if err != nil {
+		return
+	}
+	lat, lon, _ := p.Geo()
+	_, γ, k = prj.fwdtm(lat, angNormalize(lon-prj.par["lon0"]))
+	return
+}
+
+func (prj *TransverseMercator) initm() {
+	lat0 := prj.par["lat0"]
+	k0 := prj.par["k0"]
+	n := prj.sph.Fpp()
+	//
+	prj.e = math.Sqrt(prj.sph.E2())
+	prj.kA = k0 * prj.sph.RectifyingRadius()
+	prj.kM0 = k0 * prj.sph.MeridianDistance(lat0)
+	prj.α = tmαf(n)
+	prj.β = tmβf(n)
+}
+
+// fwdtm -- computes ζ=ξ+iη (scaled by k0⋅A), the meridian convergence γ (degrees),
+// and the point scale k for the latitude `lat` and the longitude `λ` (degrees)
+// relative to the central meridian.
+func (prj TransverseMercator) fwdtm(lat, λ float64) (ζ complex128, γ, k float64) {
+	e, e2 := prj.e, prj.sph.E2()
+	sφ, cφ := mym.SinCosD(lat)
+	sλ, cλ := mym.SinCosD(λ)
+	// the conformal latitude χ: sin(χ) = tanh(ψ), cos(χ) = sech(ψ),
+	// where ψ is the isometric latitude
+	var sχ, cχ, m float64
+	if cφ == 0 {
+		sχ, cχ = math.Copysign(1, sφ), 0
+		// lim cos(χ)/cos(φ) = exp(e⋅atanh(e)) at the poles
+		m = math.Sqrt(1-e2) * math.Exp(e*math.Atanh(e))
+	} else {
+		ψ := math.Asinh(sφ/cφ) - e*math.Atanh(e*sφ)
+		sχ, cχ = math.Tanh(ψ), 1/math.Cosh(ψ)
+		// the scale of the conformal sphere relative to the spheroid
+		m = math.Sqrt(1-e2*sφ*sφ) * cχ / cφ
+	}
+	// the spherical transverse Mercator
+	ξp := math.Atan2(sχ, cχ*cλ)
+	ηp := math.Atanh(cχ * sλ)
+	γp := math.Atan2(sλ*sχ, cλ)
+	kp := 1 / math.Sqrt(sχ*sχ+mym.Sq(cχ*cλ))
+	// ζ = ζ' + ∑ α[j]⋅sin(2jζ'), dζ/dζ' = 1 + ∑ 2j⋅α[j]⋅cos(2jζ')
+	ζp := complex(ξp, ηp)
+	ζ, dζ := ζp, complex(1, 0)
+	for j := 1; j <= 6; j++ {
+		w := complex(float64(2*j), 0) * ζp
+		ζ += complex(prj.α[j], 0) * cmplx.Sin(w)
+		dζ += complex(float64(2*j)*prj.α[j], 0) * cmplx.Cos(w)
+	}
+	γ = (γp - cmplx.Phase(dζ)) * (180 / math.Pi)
+	k = prj.kA / prj.sph.A() * m * kp * cmplx.Abs(dζ)
+	return
+}
+
+// tmαf -- computes the coefficients of the Krüger series
+// from the conformal latitude to the rectifying latitude.
+func tmαf(n float64) (α [7]float64) {
+	coeff := [...]float64{
+		31564, -66675, 34440, 47250, -100800, 75600, 151200,
+		-1983433, 863232, 748608, -1161216, 524160, 1935360,
+		670412, 406647, -533952, 184464, 725760,
+		6601661, -7732800, 2230245, 7257600,
+		-13675556, 3438171, 7983360,
+		212378941, 319334400,
+	}
+	return tmcoeff(coeff[:], n)
+}
+
+// tmβf -- computes the coefficients of the Krüger series
+// from the rectifying latitude to the conformal latitude.
+func tmβf(n float64) (β [7]float64) {
+	coeff := [...]float64{
+		384796, -382725, -6720, 932400, -1612800, 1209600, 2419200,
+		-1118711, 1695744, -1174656, 258048, 80640, 3870720,
+		22276, -16929, -15984, 12852, 362880,
+		-830251, -158400, 197865, 7257600,
+		-435388, 453717, 15966720,
+		20648693, 638668800,
+	}
+	return tmcoeff(coeff[:], n)
+}
+
+// tmcoeff -- evaluates C[j] = nʲ⋅P[j](n)/d[j], j=1,...,6, where the coefficients
+// of the polynomials P[j] (the highest degree first) are followed by the denominators d[j].
+func tmcoeff(coeff []float64, n float64) (C [7]float64) {
+	d := n
+	oo := 0
+	for j := 1; j <= 6; j++ {
+		m := 6 - j
+		C[j] = d * polyval(m, coeff, oo, n) / coeff[oo+m+1]
+		oo += m + 2
+		d *= n
+	}
+	return
+}
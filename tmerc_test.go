@@ -0,0 +1,87 @@
+package geomys
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestTransverseMercatorSnyder(t *testing.T) {
+	// Snyder, J.P. Map Projections: A Working Manual (1987), p.269
+	prj := NewTransverseMercator(Clarke1866(), 0, -75, 0.9996, 0, 0)
+	xy, _, k := prj.ProjectExt(Geo(40.5, -73.5, 0))
+	if math.Abs(xy[0]-127106.5) > 0.1 || math.Abs(xy[1]-4484124.4) > 0.1 {
+		t.Errorf("ProjectExt: got %v", xy)
+	}
+	if math.Abs(k-0.9997989) > 1e-7 {
+		t.Errorf("ProjectExt: got k=%v", k)
+	}
+}
+
+func TestTransverseMercatorExact(t *testing.T) {
+	// the exact transverse Mercator on WGS1984 with k0=0.9996 computed by the analytic continuation
+	// of the meridian distance: y+i⋅x = k0⋅m(φ(ψ+i⋅λ)), where ψ is the isometric latitude,
+	// see Lee, L.P. Conformal Projections Based on Elliptic Functions (1976)
+	tests := []struct {
+		lat, lon, x, y, γ, k float64
+	}{
+		{0, 30, 3503410.9361466235, 0, 0, 1.1555383280147973},
+		{0, 35, 4164389.626846203, 0, 0, 1.2223096480225153},
+		{10, 35, 4082350.708088544, 1344969.6624391875, 6.953768095932136, 1.2131705488235978},
+		{20, 38, 4212831.784785522, 2745965.606621818, 15.003860552456073, 1.2271023390877098},
+		{30, 40, 4008124.5065415506, 4099999.746952643, 22.807054585964604, 1.2042277910383228},
+		{45, 48, 3728924.28694481, 6233495.700824621, 38.17906527876985, 1.1750647455723258},
+		{60, 65, 3123174.0294848797, 8468583.239541776, 61.71033206019649, 1.1212070993208423},
+		{-25, -37, -3904387.4751293985, -3353744.167951808, 17.70394405567841, 1.1937565995651596},
+	}
+	prj := NewTransverseMercator(WGS1984(), 0, 0, 0.9996, 0, 0)
+	for _, tt := range tests {
+		xy, γ, k := prj.ProjectExt(Geo(tt.lat, tt.lon, 0))
+		if math.Abs(xy[0]-tt.x) > 1e-3 || math.Abs(xy[1]-tt.y) > 1e-3 {
+			t.Errorf("ProjectExt(%v,%v): got %v", tt.lat, tt.lon, xy)
+		}
+		if math.Abs(γ-tt.γ) > 1e-9 || math.Abs(k-tt.k) > 1e-9 {
+			t.Errorf("ProjectExt(%v,%v): got γ=%v k=%v", tt.lat, tt.lon, γ, k)
+		}
+		p, γ, k, err := prj.UnprojectExt([2]float64{tt.x, tt.y})
+		lat, lon, _ := p.Geo()
+		if err != nil || math.Abs(lat-tt.lat) > 1e-9 || math.Abs(lon-tt.lon) > 1e-9 {
+			t.Errorf("UnprojectExt(%v,%v): got (%v,%v),%v", tt.x, tt.y, lat, lon, err)
+		}
+		if math.Abs(γ-tt.γ) > 1e-9 || math.Abs(k-tt.k) > 1e-9 {
+			t.Errorf("UnprojectExt(%v,%v): got γ=%v k=%v", tt.x, tt.y, γ, k)
+		}
+	}
+}
+
+func TestTransverseMercatorRoundTrip(t *testing.T) {
+	sph := WGS1984()
+	prj := NewTransverseMercator(sph, 10, 30, 0.9996, 500000, 100000)
+	g := NewGeodesic(sph)
+	for lat := -90.0; lat <= 90; lat += 5 {
+		for dl := -40.0; dl <= 40; dl += 5 {
+			p := Geo(lat, 30+dl, 0)
+			xy, γ, k := prj.ProjectExt(p)
+			q, γ2, k2, err := prj.UnprojectExt(xy)
+			if err != nil {
+				t.Fatalf("(%v,%v): %v", lat, 30+dl, err)
+			}
+			if d, _, _ := g.Inverse(p, q); d > 1e-7 {
+				t.Errorf("(%v,%v): d=%v", lat, 30+dl, d)
+			}
+			// the convergence is undefined at the poles
+			if math.Abs(lat) < 90 && math.Abs(γ-γ2) > 1e-9 || math.Abs(k-k2) > 1e-12 {
+				t.Errorf("(%v,%v): γ=%v,%v k=%v,%v", lat, 30+dl, γ, γ2, k, k2)
+			}
+		}
+	}
+}
+
+func TestTransverseMercatorOutOfDomain(t *testing.T) {
+	prj := NewTransverseMercator(WGS1984(), 0, 0, 0.9996, 500000, 0)
+	for _, xy := range [][2]float64{{500000, 3e7}, {500000, -3e7}, {math.Inf(1), 0}, {math.NaN(), 0}} {
+		if _, err := prj.Unproject(xy); !errors.Is(err, ErrOutOfDomain) {
+			t.Errorf("Unproject(%v): got %v", xy, err)
+		}
+	}
+}
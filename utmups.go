@@ -0,0 +1,220 @@
+package geomys
+
+import (
+	"fmt"
+	"math"
+)
+
+// UTM -- a zone of the Universal Transverse Mercator (UTM) system
+// or a polar cap of the Universal Polar Stereographic (UPS) system.
+//
+// The UTM zones 1,...,60 use the transverse Mercator projection with
+// the central meridian at 6⋅zone-183, the scale factor 0.9996,
+// the false easting 500 km, and the false northing 0 km (north) or 10000 km (south).
+// The zone 0 denotes UPS, that is the polar stereographic projection with
+// the scale factor 0.994 and the false easting and northing 2000 km.
+type UTM struct {
+	sph   Spheroid
+	par   map[string]float64
+	zone  int
+	north bool
+	tm    TransverseMercator
+	ps    PolarStereographic
+}
+
+// UTMCoord -- represents the UTM/UPS grid coordinates (meters) of a point.
+// The zone 0 denotes UPS.
+type UTMCoord struct {
+	Zone     int
+	North    bool
+	Easting  float64
+	Northing float64
+}
+
+const (
+	utmk0 = 0.9996
+	upsk0 = 0.994
+)
+
+// NewUTM -- returns the UTM zone `zone` (1,...,60) or UPS (zone 0)
+// on the spheroid `sph` in the northern (north=true) or southern hemisphere.
+// This function causes a runtime panic when zone∉[0,60].
+//
+// The projection has the following parameters:
+//
+//	zone  -- UTM zone, 0 for UPS
+//	north -- 1 for the northern hemisphere, 0 for the southern hemisphere
+//	lat0  -- latitude of the origin
+//	lon0  -- longitude of the central meridian
+//	k0    -- scale factor
+//	x0    -- false easting
+//	y0    -- false northing
+func NewUTM(sph Spheroid, zone int, north bool) UTM {
+	if !(0 <= zone && zone <= 60) {
+		panic("geomys.NewUTM: domain error: `zone`")
+	}
+	hemi := 0.0
+	if north {
+		hemi = 1
+	}
+	prj := UTM{sph: sph, zone: zone, north: north}
+	if zone == 0 {
+		lat0 := -90.0
+		if north {
+			lat0 = 90
+		}
+		prj.par = map[string]float64{"zone": 0, "north": hemi, "lat0": lat0, "lon0": 0, "k0": upsk0, "x0": 2e6, "y0": 2e6}
+		prj.ps = NewPolarStereographicA(sph, lat0, 0, upsk0, 2e6, 2e6)
+	} else {
+		lon0 := float64(6*zone - 183)
+		y0 := 10e6
+		if north {
+			y0 = 0
+		}
+		prj.par = map[string]float64{"zone": float64(zone), "north": hemi, "lat0": 0, "lon0": lon0, "k0": utmk0, "x0": 5e5, "y0": y0}
+		prj.tm = NewTransverseMercator(sph, 0, lon0, utmk0, 5e5, y0)
+	}
+	return prj
+}
+
+// Spheroid -- returns the spheroid of the map projection.
+func (prj UTM) Spheroid() Spheroid {
+	if prj.par == nil {
+		panic("geomys.UTM.Spheroid: uninitialized structure")
+	}
+	//
+	return prj.sph
+}
+
+// Params -- returns the parameters of the map projection.
+func (prj UTM) Params() map[string]float64 {
+	if prj.par == nil {
+		panic("geomys.UTM.Params: uninitialized structure")
+	}
+	//
+	par := make(map[string]float64)
+	for k, v := range prj.par {
+		par[k] = v
+	}
+	return par
+}
+
+// Zone -- returns the zone (0 for UPS) and the hemisphere of the map projection.
+func (prj UTM) Zone() (zone int, north bool) {
+	if prj.par == nil {
+		panic("geomys.UTM.Zone: uninitialized structure")
+	}
+	//
+	return prj.zone, prj.north
+}
+
+// Project -- transforms a geographic point from
+// the spheroid into a location on the plane.
+func (prj UTM) Project(p Point) (xy [2]float64) {
+	if prj.par == nil {
+		panic("geomys.UTM.Project: uninitialized structure")
+	}
+	//
+	if prj.zone == 0 {
+		return prj.ps.Project(p)
+	}
+	return prj.tm.Project(p)
+}
+
+// ProjectExt -- transforms a geographic point from the spheroid into a location on the plane,
+// also returns the meridian convergence `γ` (degrees), that is the bearing of grid north
+// clockwise from true north, and the point scale `k`.
+func (prj UTM) ProjectExt(p Point) (xy [2]float64, γ, k float64) {
+	if prj.par == nil {
+		panic("geomys.UTM.ProjectExt: uninitialized structure")
+	}
+	//
+	if prj.zone == 0 {
+		return prj.ps.ProjectExt(p)
+	}
+	return prj.tm.ProjectExt(p)
+}
+
+// Unproject -- transforms a location on the plane into
+// a geographic point on the spheroid. Returns an error wrapping
+// ErrOutOfDomain when the location is outside the domain.
+func (prj UTM) Unproject(xy [2]float64) (Point, error) {
+	if prj.par == nil {
+		panic("geomys.UTM.Unproject: uninitialized structure")
+	}
+	//
+	if prj.zone == 0 {
+		return prj.ps.Unproject(xy)
+	}
+	return prj.tm.Unproject(xy)
+}
+
+// UTMZone -- returns the standard UTM zone (1,...,60) of `p`, or 0 when `p` is
+// in a polar cap of UPS (lat<-80 or lat≥84). The exceptions for Norway (zone 32V)
+// and Svalbard (zones 31X,33X,35X,37X) are taken into account.
+func UTMZone(p Point) int {
+	lat, lon, _ := p.Geo()
+	if !(-80 <= lat && lat < 84) {
+		return 0
+	}
+	if lon == 180 {
+		lon = -180
+	}
+	ilat := int(math.Floor(lat))
+	ilon := int(math.Floor(lon))
+	zone := (ilon + 186) / 6
+	if zone > 60 {
+		zone = 60
+	}
+	switch {
+	case 56 <= ilat && ilat < 64 && zone == 31 && ilon >= 3:
+		// Norway
+		zone = 32
+	case ilat >= 72 && 0 <= ilon && ilon < 42:
+		// Svalbard
+		zone = 2*((ilon+183)/12) + 1
+	}
+	return zone
+}
+
+// ToUTM -- computes the UTM/UPS grid coordinates of `p` on the spheroid `sph`
+// in the standard zone of `p` (see UTMZone).
+func ToUTM(sph Spheroid, p Point) UTMCoord {
+	return ToUTMZone(sph, p, UTMZone(p))
+}
+
+// ToUTMZone -- computes the UTM/UPS grid coordinates of `p` on the spheroid `sph`
+// in the given zone `zone` (0 for UPS), this allows to use a single zone for data
+// spanning a zone boundary. The hemisphere is determined by the latitude of `p`.
+// This function causes a runtime panic when zone∉[0,60].
+func ToUTMZone(sph Spheroid, p Point, zone int) UTMCoord {
+	lat, _, _ := p.Geo()
+	north := lat >= 0
+	xy := NewUTM(sph, zone, north).Project(p)
+	return UTMCoord{Zone: zone, North: north, Easting: xy[0], Northing: xy[1]}
+}
+
+// FromUTM -- converts the UTM/UPS grid coordinates `c` on the spheroid `sph`
+// to a geographic point. Returns an error wrapping ErrOutOfDomain when
+// c.Zone∉[0,60], or the coordinates are outside the following ranges:
+//
+//	UTM: easting ∈ [0,1000] km, northing ∈ [0,9600] km (north) or [1000,10000] km (south);
+//	UPS: easting, northing ∈ [0,4000] km.
+func FromUTM(sph Spheroid, c UTMCoord) (Point, error) {
+	if !(0 <= c.Zone && c.Zone <= 60) {
+		return Point{}, fmt.Errorf("geomys.FromUTM: %w: `zone`", ErrOutOfDomain)
+	}
+	var emin, emax, nmin, nmax float64
+	switch {
+	case c.Zone == 0:
+		emin, emax, nmin, nmax = 0, 4e6, 0, 4e6
+	case c.North:
+		emin, emax, nmin, nmax = 0, 1e6, 0, 9.6e6
+	default:
+		emin, emax, nmin, nmax = 0, 1e6, 1e6, 10e6
+	}
+	if !(emin <= c.Easting && c.Easting <= emax && nmin <= c.Northing && c.Northing <= nmax) {
+		return Point{}, fmt.Errorf("geomys.FromUTM: %w: `c`", ErrOutOfDomain)
+	}
+	return NewUTM(sph, c.Zone, c.North).Unproject([2]float64{c.Easting, c.Northing})
+}
@@ -0,0 +1,81 @@
+package geomys
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestUTMZone(t *testing.T) {
+	cases := []struct {
+		lat, lon float64
+		zone     int
+	}{
+		{33.3, 44.4, 38}, {0, -180, 1}, {0, 180, 1}, {0, 179.9, 60},
+		{60, 4, 32}, {60, 2, 31}, {55.9, 4, 31}, {78, 8, 31}, {78, 10, 33},
+		{78, 25, 35}, {78, 40, 37}, {83.9, 41.9, 37}, {71.9, 10, 32},
+		{84, 0, 0}, {-80, 0, 31}, {-80, -100, 14}, {-80.1, 0, 0}, {90, 0, 0},
+	}
+	for _, c := range cases {
+		if zone := UTMZone(Geo(c.lat, c.lon, 0)); zone != c.zone {
+			t.Errorf("UTMZone(%v,%v): got %v, want %v", c.lat, c.lon, zone, c.zone)
+		}
+	}
+}
+
+func TestUTMGeoConvert(t *testing.T) {
+	// GeographicLib: echo 33.3 44.4 | GeoConvert -u
+	c := ToUTM(WGS1984(), Geo(33.3, 44.4, 0))
+	if c.Zone != 38 || !c.North || math.Abs(c.Easting-444140.54) > 0.01 || math.Abs(c.Northing-3684706.36) > 0.01 {
+		t.Errorf("ToUTM: got %+v", c)
+	}
+}
+
+func TestUTMRoundTrip(t *testing.T) {
+	sph := WGS1984()
+	g := NewGeodesic(sph)
+	for lat := -90.0; lat <= 90; lat += 2.5 {
+		for lon := -180.0; lon <= 180; lon += 7.5 {
+			p := Geo(lat, lon, 0)
+			c := ToUTM(sph, p)
+			q, err := FromUTM(sph, c)
+			if err != nil {
+				t.Fatalf("(%v,%v) %+v: %v", lat, lon, c, err)
+			}
+			if d, _, _ := g.Inverse(p, q); d > 1e-7 {
+				t.Errorf("(%v,%v): d=%v", lat, lon, d)
+			}
+		}
+	}
+}
+
+func TestUPSScale(t *testing.T) {
+	sph := WGS1984()
+	g := NewGeodesic(sph)
+	for _, north := range []bool{true, false} {
+		prj := NewUTM(sph, 0, north)
+		pole := Geo(90, 0, 0)
+		p := Geo(89.999, 30, 0)
+		if !north {
+			pole, p = Geo(-90, 0, 0), Geo(-89.999, 30, 0)
+		}
+		a, b := prj.Project(pole), prj.Project(p)
+		if a != [2]float64{2e6, 2e6} {
+			t.Errorf("Project(pole): got %v", a)
+		}
+		d, _, _ := g.Inverse(pole, p)
+		if k := math.Hypot(b[0]-a[0], b[1]-a[1]) / d; math.Abs(k-upsk0) > 1e-9 {
+			t.Errorf("north=%v: k=%v", north, k)
+		}
+	}
+}
+
+func TestUTMOutOfDomain(t *testing.T) {
+	sph := WGS1984()
+	for _, c := range []UTMCoord{{61, true, 5e5, 0}, {-1, true, 5e5, 0}, {31, true, -1, 0},
+		{31, false, 5e5, 9e5}, {0, true, 5e6, 2e6}, {31, true, math.NaN(), 0}} {
+		if _, err := FromUTM(sph, c); !errors.Is(err, ErrOutOfDomain) {
+			t.Errorf("FromUTM(%+v): got %v", c, err)
+		}
+	}
+}
@@ -0,0 +1,821 @@
+package geomys
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WKTVersion -- a version of the well-known text representation of coordinate reference systems.
+type WKTVersion int
+
+// The supported versions of WKT.
+const (
+	WKT2 WKTVersion = iota // OGC 18-010r7, ISO 19162:2019 (WKT2:2019)
+	WKT1                   // OGC 01-009 (WKT1), the projection and parameter names as in GDAL
+)
+
+// ErrWKTSyntax -- the error returned when a WKT string is malformed.
+var ErrWKTSyntax = errors.New("invalid WKT syntax")
+
+// wktParam -- describes a parameter of a coordinate conversion.
+type wktParam struct {
+	key   string  // the name as in Params()
+	name  string  // the WKT2 name, empty when absent in WKT2
+	epsg  int     // the EPSG code, 0 if none
+	wkt1  string  // the WKT1 name, empty when absent in WKT1
+	fixed bool    // true when the projection has no such parameter
+	value float64 // the value of the fixed parameter
+}
+
+// wktMethod -- describes the coordinate conversion of a registered map projection.
+type wktMethod struct {
+	proj   string // the name of the registered map projection
+	name   string // the WKT2 name
+	epsg   int    // the EPSG code, 0 if none
+	wkt1   string // the WKT1 name, empty when absent in WKT1
+	params []wktParam
+}
+
+// wktMethods -- the coordinate conversions in the order of preference.
+var wktMethods = func() []wktMethod {
+	p := func(key, name string, epsg int, wkt1 string) wktParam {
+		return wktParam{key: key, name: name, epsg: epsg, wkt1: wkt1}
+	}
+	fixed := func(q wktParam, v float64) wktParam {
+		q.fixed, q.value = true, v
+		return q
+	}
+	lat0 := p("lat0", "Latitude of natural origin", 8801, "latitude_of_origin")
+	lon0 := p("lon0", "Longitude of natural origin", 8802, "central_meridian")
+	k0 := p("k0", "Scale factor at natural origin", 8805, "scale_factor")
+	x0 := p("x0", "False easting", 8806, "false_easting")
+	y0 := p("y0", "False northing", 8807, "false_northing")
+	latF := p("lat0", "Latitude of false origin", 8821, "latitude_of_origin")
+	lonF := p("lon0", "Longitude of false origin", 8822, "central_meridian")
+	lat1 := p("lat1", "Latitude of 1st standard parallel", 8823, "standard_parallel_1")
+	lat2 := p("lat2", "Latitude of 2nd standard parallel", 8824, "standard_parallel_2")
+	x0F := p("x0", "Easting at false origin", 8826, "false_easting")
+	y0F := p("y0", "Northing at false origin", 8827, "false_northing")
+	latC := p("lat0", lat0.name, lat0.epsg, "latitude_of_center")
+	lonC := p("lon0", lon0.name, lon0.epsg, "longitude_of_center")
+	latS := p("lat1", "Latitude of standard parallel", 8832, "latitude_of_origin")
+	lonS := p("lon0", "Longitude of origin", 8833, "central_meridian")
+	natural := []wktParam{lat0, lon0, k0, x0, y0}
+	world := []wktParam{lon0, fixed(x0, 0), fixed(y0, 0)}
+	worldC := []wktParam{lonC, fixed(x0, 0), fixed(y0, 0)}
+	//
+	return []wktMethod{
+		{"aea", "Albers Equal Area", 9822, "Albers_Conic_Equal_Area",
+			[]wktParam{
+				p("lat0", latF.name, latF.epsg, "latitude_of_center"), p("lon0", lonF.name, lonF.epsg, "longitude_of_center"),
+				lat1, lat2, fixed(x0F, 0), fixed(y0F, 0)}},
+		{"tmerc", "Transverse Mercator", 9807, "Transverse_Mercator", natural},
+		{"lcc", "Lambert Conic Conformal (2SP)", 9802, "Lambert_Conformal_Conic_2SP",
+			[]wktParam{latF, lonF, lat1, lat2, x0F, y0F}},
+		{"lcc1sp", "Lambert Conic Conformal (1SP)", 9801, "Lambert_Conformal_Conic_1SP", natural},
+		{"merc", "Mercator (variant B)", 9805, "Mercator_2SP", []wktParam{lat1, lon0, x0, y0}},
+		// the scale factor is converted to the latitude of true scale
+		{"merc", "Mercator (variant A)", 9804, "Mercator_1SP", []wktParam{fixed(lat0, 0), lon0, k0, x0, y0}},
+		{"webmerc", "Popular Visualisation Pseudo Mercator", 1024, "Popular_Visualisation_Pseudo_Mercator",
+			[]wktParam{p("lat1", lat0.name, lat0.epsg, lat0.wkt1), lon0, x0, y0}},
+		{"stere_a", "Polar Stereographic (variant A)", 9810, "Polar_Stereographic", natural},
+		{"stere_b", "Polar Stereographic (variant B)", 9829, "Polar_Stereographic",
+			[]wktParam{latS, lonS, fixed(p("k0", "", 0, "scale_factor"), 1), x0, y0}},
+		{"stere_c", "Polar Stereographic (variant C)", 9830, "", []wktParam{latS, lonS, x0F, y0F}},
+		{"sterea", "Oblique Stereographic", 9809, "Oblique_Stereographic", natural},
+		{"aeqd", "Azimuthal Equidistant", 1125, "Azimuthal_Equidistant", []wktParam{latC, lonC, x0, y0}},
+		{"gnom", "Gnomonic", 0, "Gnomonic", []wktParam{lat0, lon0, x0, y0}},
+		{"laea", "Lambert Azimuthal Equal Area", 9820, "Lambert_Azimuthal_Equal_Area", []wktParam{latC, lonC, x0, y0}},
+		{"eqearth", "Equal Earth", 1078, "Equal_Earth", world},
+		{"moll", "Mollweide", 0, "Mollweide", world},
+		{"robin", "Robinson", 0, "Robinson", worldC},
+		{"wintri", "Winkel Tripel", 0, "Winkel_Tripel", world},
+		{"natearth", "Natural Earth", 0, "Natural_Earth", worldC},
+	}
+}()
+
+// wktAliases -- the alternative WKT1 parameter names (normalized).
+var wktAliases = map[string]string{
+	"latitudeofcenter":  "latitudeoforigin",
+	"longitudeofcenter": "centralmeridian",
+	"longitudeoforigin": "centralmeridian",
+}
+
+// wktEllps -- the names of the known spheroids.
+var wktEllps = []struct {
+	name string
+	sph  Spheroid
+}{
+	{"WGS 84", WGS1984()},
+	{"GRS 1980", GRS1980()},
+	{"WGS 72", WGS1972()},
+	{"GRS 1967", GRS1967()},
+	{"International 1924", International1924()},
+	{"Clarke 1866", Clarke1866()},
+	{"Airy 1830", projEllps["airy"]()},
+	{"Bessel 1841", projEllps["bessel"]()},
+}
+
+const (
+	wktDegree = `"degree",0.0174532925199433`
+	wktMetre  = `"metre",1`
+)
+
+// FormatSpheroidWKT -- returns the WKT representation of the spheroid `sph`, that is
+// ELLIPSOID[name,a,1/f,LENGTHUNIT["metre",1]] (WKT2) or SPHEROID[name,a,1/f] (WKT1),
+// where 1/f is 0 for a sphere.
+func FormatSpheroidWKT(sph Spheroid, ver WKTVersion) string {
+	name, known := "unknown", false
+	for _, e := range wktEllps {
+		if e.sph == sph {
+			name, known = e.name, true
+			break
+		}
+	}
+	if ver == WKT1 {
+		return fmt.Sprintf("SPHEROID[%s,%s,%s]", wktQuote(name), wktNum(sph.A()), wktRf(sph.F(), known))
+	}
+	return fmt.Sprintf("ELLIPSOID[%s,%s,%s,LENGTHUNIT[%s]]", wktQuote(name), wktNum(sph.A()), wktRf(sph.F(), known), wktMetre)
+}
+
+// FormatWKT -- returns the WKT representation of the map projection `prj` as a projected CRS
+// with the easting and northing axes in metres, or in the unit and the axis order of a Grid.
+// The false easting and northing of a Grid are added to those of the underlying projection.
+// Returns an error wrapping ErrUnknownProjection when `prj` has no WKT representation.
+func FormatWKT(prj MapProjection, ver WKTVersion) (string, error) {
+	const fn = "geomys.FormatWKT"
+	off, unit, swap := [2]float64{}, 1.0, false
+	if g, ok := prj.(Grid); ok {
+		gp := g.Params()
+		off, unit, swap = [2]float64{gp["fe"], gp["fn"]}, gp["unit"], gp["axis"] == 1
+		prj = g.Projection()
+	}
+	name, ok := projectionName(prj)
+	if !ok {
+		return "", fmt.Errorf("%s: %w: `%T`", fn, ErrUnknownProjection, prj)
+	}
+	sph, par := prj.Spheroid(), prj.Params()
+	title := "unknown"
+	if utm, ok := prj.(UTM); ok {
+		zone, north := utm.Zone()
+		title, name = wktUTMName(zone, north), "tmerc"
+		if zone == 0 {
+			name = "stere_a"
+		}
+	}
+	var m *wktMethod
+	for i := range wktMethods {
+		if wktMethods[i].proj == name {
+			m = &wktMethods[i]
+			break
+		}
+	}
+	if m == nil || ver == WKT1 && m.wkt1 == "" {
+		return "", fmt.Errorf("%s: %w: `%s`", fn, ErrUnknownProjection, name)
+	}
+	value := func(q wktParam) float64 {
+		v := q.value
+		if !q.fixed {
+			v = par[q.key]
+		}
+		switch q.key {
+		case "x0":
+			v += off[0]
+		case "y0":
+			v += off[1]
+		}
+		return v
+	}
+	axes := [2][3]string{{"Easting", "easting (E)", "east"}, {"Northing", "northing (N)", "north"}}
+	if swap {
+		axes[0], axes[1] = axes[1], axes[0]
+	}
+	lunit := wktLengthUnit(unit)
+	//
+	var b strings.Builder
+	if ver == WKT1 {
+		fmt.Fprintf(&b, "PROJCS[%s,GEOGCS[\"unknown\",DATUM[\"unknown\",%s],PRIMEM[\"Greenwich\",0],UNIT[%s]],PROJECTION[%s]",
+			wktQuote(title), FormatSpheroidWKT(sph, WKT1), wktDegree, wktQuote(m.wkt1))
+		for _, q := range m.params {
+			if q.wkt1 == "" {
+				continue
+			}
+			// the linear parameters are in the unit of the CRS
+			v := value(q)
+			if wktKind(q.key) == 'l' {
+				v /= unit
+			}
+			fmt.Fprintf(&b, ",PARAMETER[%s,%s]", wktQuote(q.wkt1), wktNum(v))
+		}
+		fmt.Fprintf(&b, ",UNIT[%s]", lunit)
+		for _, ax := range axes {
+			fmt.Fprintf(&b, ",AXIS[%s,%s]", wktQuote(ax[0]), strings.ToUpper(ax[2]))
+		}
+		b.WriteString("]")
+		return b.String(), nil
+	}
+	fmt.Fprintf(&b, "PROJCRS[%s,BASEGEOGCRS[\"unknown\",DATUM[\"unknown\",%s],PRIMEM[\"Greenwich\",0,ANGLEUNIT[%s]]]",
+		wktQuote(title), FormatSpheroidWKT(sph, WKT2), wktDegree)
+	fmt.Fprintf(&b, ",CONVERSION[%s,METHOD[%s%s]", wktQuote(title), wktQuote(m.name), wktID(m.epsg))
+	for _, q := range m.params {
+		if q.name == "" {
+			continue
+		}
+		unit := "LENGTHUNIT[" + wktMetre + "]"
+		switch wktKind(q.key) {
+		case 'a':
+			unit = "ANGLEUNIT[" + wktDegree + "]"
+		case 's':
+			unit = `SCALEUNIT["unity",1]`
+		}
+		fmt.Fprintf(&b, ",PARAMETER[%s,%s,%s%s]", wktQuote(q.name), wktNum(value(q)), unit, wktID(q.epsg))
+	}
+	b.WriteString("],CS[Cartesian,2]")
+	for i, ax := range axes {
+		fmt.Fprintf(&b, ",AXIS[%s,%s,ORDER[%d],LENGTHUNIT[%s]]", wktQuote(ax[1]), ax[2], i+1, lunit)
+	}
+	b.WriteString("]")
+	return b.String(), nil
+}
+
+// ParseSpheroidWKT -- returns the spheroid defined by the first ELLIPSOID (WKT2) or SPHEROID (WKT1)
+// in the WKT string `s`, which can also be a datum or a CRS.
+// Returns an error wrapping ErrWKTSyntax, ErrMissingParam, or ErrInvalidParam.
+func ParseSpheroidWKT(s string) (Spheroid, error) {
+	const fn = "geomys.ParseSpheroidWKT"
+	root, err := wktParse(fn, s)
+	if err != nil {
+		return Spheroid{}, err
+	}
+	return wktSpheroid(fn, root)
+}
+
+// ParseWKT -- builds a map projection from the projected CRS `s` in WKT2 (PROJCRS)
+// or WKT1 (PROJCS). The coordinate conversion is identified by its EPSG code or by its name,
+// the parameters are converted to degrees and metres. The CRS named "UTM zone ZZN",
+// "UTM zone ZZS", or "Universal Polar Stereographic North/South" builds UTM
+// when its parameters agree with the zone. The prime meridian must be Greenwich.
+// The result is wrapped by NewGrid when the linear unit is not the metre, the axis order
+// is northing-first, or the projection has no false easting and northing of its own,
+// while they are not zero.
+//
+// Returns an error wrapping ErrWKTSyntax, ErrUnknownProjection, ErrUnknownParam,
+// ErrMissingParam, or ErrInvalidParam, where the parameters are named as in `s`.
+func ParseWKT(s string) (MapProjection, error) {
+	const fn = "geomys.ParseWKT"
+	root, err := wktParse(fn, s)
+	if err != nil {
+		return nil, err
+	}
+	var conv, base *wktNode
+	switch strings.ToUpper(root.key) {
+	case "PROJCRS", "PROJECTEDCRS":
+		conv = root.find("CONVERSION", "DERIVINGCONVERSION")
+		base = root.find("BASEGEOGCRS", "BASEGEODCRS")
+	case "PROJCS":
+		conv = root
+		base = root.find("GEOGCS")
+	default:
+		return nil, fmt.Errorf("%s: %w: `%s`", fn, ErrUnknownProjection, root.key)
+	}
+	if conv == nil || base == nil {
+		return nil, fmt.Errorf("%s: %w: `%s`", fn, ErrMissingParam, root.key)
+	}
+	sph, err := wktSpheroid(fn, base)
+	if err != nil {
+		return nil, err
+	}
+	if pm := base.find("PRIMEM", "PRIMEMERIDIAN"); pm != nil {
+		if lon, ok := pm.number(1); !ok || lon != 0 {
+			return nil, fmt.Errorf("%s: %w: `%s`", fn, ErrInvalidParam, pm.key)
+		}
+	}
+	unit, swap, err := wktAxes(fn, root)
+	if err != nil {
+		return nil, err
+	}
+	// the default units of the parameters
+	angle, length := 1.0, 1.0
+	if conv == root {
+		length = unit
+		if u := base.find("UNIT", "ANGLEUNIT"); u != nil {
+			if angle, err = wktUnit(fn, u, true); err != nil {
+				return nil, err
+			}
+		}
+	}
+	//
+	method := conv.find("METHOD", "PROJECTION")
+	if method == nil {
+		return nil, fmt.Errorf("%s: %w: `%s`", fn, ErrMissingParam, "METHOD")
+	}
+	code := method.epsg()
+	var cands []*wktMethod
+	for i, m := range wktMethods {
+		if code != 0 && m.epsg == code || code == 0 && (wktNorm(m.name) == wktNorm(method.text()) || m.wkt1 != "" && wktNorm(m.wkt1) == wktNorm(method.text())) {
+			cands = append(cands, &wktMethods[i])
+		}
+	}
+	if len(cands) == 0 {
+		return nil, fmt.Errorf("%s: %w: `%s`", fn, ErrUnknownProjection, method.text())
+	}
+	var first error
+	for _, m := range cands {
+		prj, off, err := wktBuild(fn, m, sph, conv.all("PARAMETER"), angle, length)
+		if err != nil {
+			if first == nil {
+				first = err
+			}
+			continue
+		}
+		if zone, north, ok := wktUTM(root.text(), conv.text()); ok && (m.proj == "tmerc" || m.proj == "stere_a") {
+			par := prj.Params()
+			par["zone"], par["north"] = float64(zone), 0
+			if north {
+				par["north"] = 1
+			}
+			if utm, err := NewProjection("utm", sph, par); err == nil {
+				prj = utm
+			}
+		}
+		if off != [2]float64{} || unit != 1 || swap {
+			return NewGrid(prj, off[0], off[1], unit, swap), nil
+		}
+		return prj, nil
+	}
+	return nil, first
+}
+
+// wktBuild -- builds the map projection from the coordinate conversion `m` with the parameters `params`,
+// which are in the units of `angle` degrees and `length` meters by default. Also returns the false easting
+// and northing, when the projection has no such parameters.
+func wktBuild(fn string, m *wktMethod, sph Spheroid, params []*wktNode, angle, length float64) (prj MapProjection, off [2]float64, err error) {
+	par := make(map[string]float64)
+	names := make(map[string]string)
+	for _, pn := range params {
+		name, code := pn.text(), pn.epsg()
+		var q *wktParam
+		for i := range m.params {
+			d := &m.params[i]
+			if code != 0 && d.epsg == code ||
+				code == 0 && (d.name != "" && wktNorm(d.name) == wktNorm(name) || d.wkt1 != "" && wktNorm(d.wkt1) == wktNorm(name)) {
+				q = d
+				break
+			}
+		}
+		if q == nil {
+			return nil, off, fmt.Errorf("%s: %w: `%s`", fn, ErrUnknownParam, name)
+		}
+		if _, ok := names[q.key]; ok {
+			return nil, off, fmt.Errorf("%s: %w: `%s`", fn, ErrWKTSyntax, name)
+		}
+		v, ok := pn.number(1)
+		if !ok {
+			return nil, off, fmt.Errorf("%s: %w: `%s`", fn, ErrInvalidParam, name)
+		}
+		kind := wktKind(q.key)
+		unit := 1.0
+		switch kind {
+		case 'a':
+			unit = angle
+		case 'l':
+			unit = length
+		}
+		if u := pn.find("UNIT", "ANGLEUNIT", "LENGTHUNIT", "SCALEUNIT"); u != nil {
+			var err error
+			if unit, err = wktUnit(fn, u, kind == 'a'); err != nil {
+				return nil, off, err
+			}
+		}
+		v *= unit
+		if math.IsInf(v, 0) {
+			return nil, off, fmt.Errorf("%s: %w: `%s`", fn, ErrInvalidParam, name)
+		}
+		names[q.key] = name
+		switch {
+		case q.fixed && q.key == "x0":
+			off[0] = v
+			continue
+		case q.fixed && q.key == "y0":
+			off[1] = v
+			continue
+		case q.fixed:
+			if v != q.value {
+				return nil, off, fmt.Errorf("%s: %w: `%s`", fn, ErrInvalidParam, name)
+			}
+			continue
+		}
+		par[q.key] = v
+	}
+	if k0, ok := par["k0"]; ok && m.proj == "merc" {
+		lat1, ok := mercLat1(sph, k0)
+		if !ok {
+			return nil, off, fmt.Errorf("%s: %w: `%s`", fn, ErrInvalidParam, names["k0"])
+		}
+		delete(par, "k0")
+		par["lat1"], names["lat1"] = lat1, names["k0"]
+	}
+	prj, err = newProjection(fn, m.proj, sph, par, func(k string) string {
+		if name, ok := names[k]; ok {
+			return name
+		}
+		for _, q := range m.params {
+			if q.key == k && q.name != "" {
+				return q.name
+			}
+		}
+		return k
+	})
+	return prj, off, err
+}
+
+// wktSpheroid -- returns the spheroid defined by the first ELLIPSOID or SPHEROID in `root`.
+func wktSpheroid(fn string, root *wktNode) (Spheroid, error) {
+	e := root.search("ELLIPSOID", "SPHEROID")
+	if e == nil {
+		return Spheroid{}, fmt.Errorf("%s: %w: `ELLIPSOID`", fn, ErrMissingParam)
+	}
+	a, ok1 := e.number(1)
+	rf, ok2 := e.number(2)
+	if u := e.find("LENGTHUNIT", "UNIT"); u != nil {
+		unit, err := wktUnit(fn, u, false)
+		if err != nil {
+			return Spheroid{}, err
+		}
+		a *= unit
+	}
+	if !ok1 || !ok2 || !(1 <= a && a <= 1e22) || !(rf == 0 || rf >= 150 && rf < math.Inf(1)) {
+		return Spheroid{}, fmt.Errorf("%s: %w: `%s`", fn, ErrInvalidParam, e.key)
+	}
+	if rf == 0 {
+		return NewSphere(a), nil
+	}
+	for _, k := range wktEllps {
+		if k.sph.A() == a && math.Abs(rf*k.sph.F()-1) <= 1e-11 {
+			return k.sph, nil
+		}
+	}
+	return NewSpheroid(a, 1/rf), nil
+}
+
+// wktAxes -- returns the linear unit (meters per unit) and the axis order (swap=true for
+// the northing-first order) of the projected CRS `root`.
+func wktAxes(fn string, root *wktNode) (unit float64, swap bool, err error) {
+	var axes []*wktNode
+	if cs := root.find("CS"); cs != nil {
+		axes = cs.all("AXIS")
+	}
+	axes = append(axes, root.all("AXIS")...)
+	order := func(ax *wktNode) float64 {
+		if o := ax.find("ORDER"); o != nil {
+			if n, ok := o.number(0); ok {
+				return n
+			}
+		}
+		return 0
+	}
+	sort.SliceStable(axes, func(i, j int) bool { return order(axes[i]) < order(axes[j]) })
+	dir := make([]string, len(axes))
+	units := root.all("UNIT", "LENGTHUNIT")
+	for i, ax := range axes {
+		if len(ax.args) > 1 {
+			dir[i] = strings.ToLower(ax.args[1].key)
+		}
+		units = append(units, ax.all("LENGTHUNIT", "UNIT")...)
+	}
+	// all the units must agree
+	unit = 1
+	for i, u := range units {
+		f, err := wktUnit(fn, u, false)
+		if err != nil {
+			return 0, false, err
+		}
+		if i > 0 && f != unit {
+			return 0, false, fmt.Errorf("%s: %w: `%s`", fn, ErrInvalidParam, u.key)
+		}
+		unit = f
+	}
+	switch {
+	case len(axes) == 0:
+		return unit, false, nil
+	case len(axes) != 2:
+	case dir[0] == "east" && dir[1] == "north":
+		return unit, false, nil
+	case dir[0] == "north" && dir[1] == "east":
+		return unit, true, nil
+	case dir[0] == "north" && dir[1] == "north" || dir[0] == "south" && dir[1] == "south":
+		// the polar axes along the meridians
+		return unit, false, nil
+	}
+	return 0, false, fmt.Errorf("%s: %w: `AXIS`", fn, ErrInvalidParam)
+}
+
+// wktUnit -- returns the conversion factor of the unit `u` to degrees (angle=true) or metres.
+// The factors of the known linear units are rounded to their exact values.
+func wktUnit(fn string, u *wktNode, angle bool) (float64, error) {
+	f, ok := u.number(1)
+	if !ok || !(f > 0 && f < math.Inf(1)) {
+		return 0, fmt.Errorf("%s: %w: `%s`", fn, ErrInvalidParam, u.key)
+	}
+	if angle {
+		f /= math.Pi / 180
+		if math.Abs(f-1) <= 1e-12 {
+			f = 1
+		}
+		return f, nil
+	}
+	for _, k := range []float64{UnitMetre, UnitKilometre, UnitFoot, UnitUSSurveyFoot} {
+		if math.Abs(f/k-1) <= 1e-12 {
+			return k, nil
+		}
+	}
+	return f, nil
+}
+
+// wktUTMName -- returns the name of the UTM zone `zone` (0 for UPS).
+func wktUTMName(zone int, north bool) string {
+	switch {
+	case zone == 0 && north:
+		return "Universal Polar Stereographic North"
+	case zone == 0:
+		return "Universal Polar Stereographic South"
+	case north:
+		return fmt.Sprintf("UTM zone %dN", zone)
+	}
+	return fmt.Sprintf("UTM zone %dS", zone)
+}
+
+// wktUTM -- recognizes a UTM zone or UPS in the `names`.
+func wktUTM(names ...string) (zone int, north, ok bool) {
+	for _, name := range names {
+		switch {
+		case strings.Contains(name, "Universal Polar Stereographic North") || strings.Contains(name, "UPS North"):
+			return 0, true, true
+		case strings.Contains(name, "Universal Polar Stereographic South") || strings.Contains(name, "UPS South"):
+			return 0, false, true
+		}
+		i := strings.LastIndex(name, "UTM zone ")
+		if i < 0 {
+			continue
+		}
+		z := name[i+len("UTM zone "):]
+		if len(z) < 2 || !(z[len(z)-1] == 'N' || z[len(z)-1] == 'S') {
+			continue
+		}
+		n, err := strconv.Atoi(z[:len(z)-1])
+		if err != nil || !(1 <= n && n <= 60) {
+			continue
+		}
+		return n, z[len(z)-1] == 'N', true
+	}
+	return 0, false, false
+}
+
+// wktKind -- returns 'a' for an angle, 's' for a scale, or 'l' for a length parameter.
+func wktKind(key string) byte {
+	switch {
+	case strings.HasPrefix(key, "lat") || strings.HasPrefix(key, "lon"):
+		return 'a'
+	case key == "k0":
+		return 's'
+	}
+	return 'l'
+}
+
+// wktNorm -- normalizes a WKT name for comparison.
+func wktNorm(name string) string {
+	var b strings.Builder
+	for _, c := range strings.ToLower(name) {
+		if 'a' <= c && c <= 'z' || '0' <= c && c <= '9' {
+			b.WriteRune(c)
+		}
+	}
+	if alias, ok := wktAliases[b.String()]; ok {
+		return alias
+	}
+	return b.String()
+}
+
+// wktLengthUnit -- returns the name and the conversion factor of the linear unit `unit`.
+func wktLengthUnit(unit float64) string {
+	name := "unknown"
+	switch unit {
+	case UnitMetre:
+		name = "metre"
+	case UnitKilometre:
+		name = "kilometre"
+	case UnitFoot:
+		name = "foot"
+	case UnitUSSurveyFoot:
+		name = "US survey foot"
+	}
+	return wktQuote(name) + "," + wktNum(unit)
+}
+
+func wktQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+func wktNum(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// wktRf -- returns the inverse flattening 1/f rounded to 15 digits, when it restores `f`
+// or the spheroid is known, otherwise in the shortest form that restores `f`.
+func wktRf(f float64, known bool) string {
+	if f == 0 {
+		return "0"
+	}
+	rf, _ := strconv.ParseFloat(strconv.FormatFloat(1/f, 'g', 15, 64), 64)
+	if !known && 1/rf != f {
+		rf = 1 / f
+	}
+	return wktNum(rf)
+}
+
+func wktID(epsg int) string {
+	if epsg == 0 {
+		return ""
+	}
+	return fmt.Sprintf(`,ID["EPSG",%d]`, epsg)
+}
+
+// wktNode -- a keyword with its arguments, a quoted text (quoted=true), or a number (empty key).
+type wktNode struct {
+	key    string
+	args   []*wktNode
+	str    string
+	quoted bool
+	num    float64
+}
+
+// find -- returns the first argument of `n` that is one of the `keys`.
+func (n *wktNode) find(keys ...string) *wktNode {
+	all := n.all(keys...)
+	if len(all) == 0 {
+		return nil
+	}
+	return all[0]
+}
+
+// all -- returns the arguments of `n` that are any of the `keys`.
+func (n *wktNode) all(keys ...string) []*wktNode {
+	var found []*wktNode
+	for _, a := range n.args {
+		for _, k := range keys {
+			if strings.EqualFold(a.key, k) {
+				found = append(found, a)
+				break
+			}
+		}
+	}
+	return found
+}
+
+// search -- returns the first node in the subtree of `n` that is one of the `keys`.
+func (n *wktNode) search(keys ...string) *wktNode {
+	for _, k := range keys {
+		if strings.EqualFold(n.key, k) {
+			return n
+		}
+	}
+	for _, a := range n.args {
+		if found := a.search(keys...); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// text -- returns the first argument of `n` when it is a quoted text.
+func (n *wktNode) text() string {
+	if len(n.args) > 0 && n.args[0].quoted {
+		return n.args[0].str
+	}
+	return ""
+}
+
+// number -- returns the argument `i` of `n` when it is a number.
+func (n *wktNode) number(i int) (float64, bool) {
+	if i < len(n.args) && n.args[i].key == "" && !n.args[i].quoted {
+		return n.args[i].num, true
+	}
+	return 0, false
+}
+
+// epsg -- returns the EPSG code in ID (WKT2) or AUTHORITY (WKT1) of `n`, or 0.
+func (n *wktNode) epsg() int {
+	for _, id := range n.all("ID", "AUTHORITY") {
+		if !strings.EqualFold(id.text(), "EPSG") || len(id.args) < 2 {
+			continue
+		}
+		if c, ok := id.number(1); ok {
+			return int(c)
+		}
+		if c, err := strconv.Atoi(id.args[1].str); err == nil {
+			return c
+		}
+	}
+	return 0
+}
+
+// wktParse -- parses the WKT string `s` into a tree.
+func wktParse(fn string, s string) (*wktNode, error) {
+	p := wktParser{s: s}
+	root, ok := p.value()
+	if ok {
+		p.skip()
+		ok = p.pos == len(p.s) && len(root.args) > 0
+	}
+	if !ok {
+		near := p.s[p.pos:]
+		if len(near) > 16 {
+			near = near[:16]
+		}
+		return nil, fmt.Errorf("%s: %w: `%s`", fn, ErrWKTSyntax, near)
+	}
+	return root, nil
+}
+
+// wktParser -- a recursive descent parser of WKT, `pos` is the current position in `s`.
+type wktParser struct {
+	s   string
+	pos int
+}
+
+func (p *wktParser) skip() {
+	for p.pos < len(p.s) && strings.IndexByte(" \t\r\n", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// value -- parses a keyword with its arguments, a quoted text, or a number.
+func (p *wktParser) value() (*wktNode, bool) {
+	p.skip()
+	if p.pos == len(p.s) {
+		return nil, false
+	}
+	isLetter := func(c byte) bool { return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' }
+	switch c := p.s[p.pos]; {
+	case c == '"':
+		// a quote inside the text is doubled
+		var b strings.Builder
+		for p.pos++; p.pos < len(p.s); p.pos++ {
+			if p.s[p.pos] == '"' {
+				if p.pos+1 < len(p.s) && p.s[p.pos+1] == '"' {
+					b.WriteByte('"')
+					p.pos++
+					continue
+				}
+				p.pos++
+				return &wktNode{str: b.String(), quoted: true}, true
+			}
+			b.WriteByte(p.s[p.pos])
+		}
+		return nil, false
+	case isLetter(c):
+		start := p.pos
+		for p.pos < len(p.s) && (isLetter(p.s[p.pos]) || '0' <= p.s[p.pos] && p.s[p.pos] <= '9' || p.s[p.pos] == '_') {
+			p.pos++
+		}
+		n := &wktNode{key: p.s[start:p.pos]}
+		p.skip()
+		if p.pos == len(p.s) || !(p.s[p.pos] == '[' || p.s[p.pos] == '(') {
+			// an enumeration
+			return n, true
+		}
+		end := byte(']')
+		if p.s[p.pos] == '(' {
+			end = ')'
+		}
+		for p.pos++; ; p.pos++ {
+			a, ok := p.value()
+			if !ok {
+				return nil, false
+			}
+			n.args = append(n.args, a)
+			p.skip()
+			if p.pos < len(p.s) && p.s[p.pos] == end {
+				p.pos++
+				return n, true
+			}
+			if !(p.pos < len(p.s) && p.s[p.pos] == ',') {
+				return nil, false
+			}
+		}
+	}
+	start := p.pos
+	for p.pos < len(p.s) && strings.IndexByte("+-.0123456789eE", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+	x, err := strconv.ParseFloat(p.s[start:p.pos], 64)
+	if err != nil {
+		p.pos = start
+		return nil, false
+	}
+	return &wktNode{num: x}, true
+}
@@ -0,0 +1,222 @@
+package geomys
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSpheroidWKT(t *testing.T) {
+	sphs := []Spheroid{WGS1984(), GRS1980(), Clarke1866(), International1924(), NewSpheroid(6400000, 1/300.5), NewSphere(6371000)}
+	for _, sph := range sphs {
+		for _, ver := range []WKTVersion{WKT2, WKT1} {
+			s := FormatSpheroidWKT(sph, ver)
+			sph2, err := ParseSpheroidWKT(s)
+			if err != nil || sph2 != sph {
+				t.Errorf("%s: got %v, %v", s, sph2, err)
+			}
+		}
+	}
+	if s := FormatSpheroidWKT(GRS1980(), WKT2); s != `ELLIPSOID["GRS 1980",6378137,298.257222101,LENGTHUNIT["metre",1]]` {
+		t.Errorf("GRS1980: got %s", s)
+	}
+	if s := FormatSpheroidWKT(WGS1984(), WKT1); s != `SPHEROID["WGS 84",6378137,298.257223563]` {
+		t.Errorf("WGS1984: got %s", s)
+	}
+	// EPSG:4267
+	s := `GEOGCRS["NAD27",DATUM["North American Datum 1927",
+		ELLIPSOID["Clarke 1866",6378206.4,294.978698213898,LENGTHUNIT["metre",1]]],
+		PRIMEM["Greenwich",0,ANGLEUNIT["degree",0.0174532925199433]],ID["EPSG",4267]]`
+	if sph, err := ParseSpheroidWKT(s); err != nil || sph != Clarke1866() {
+		t.Errorf("NAD27: got %v, %v", sph, err)
+	}
+	if sph, err := ParseSpheroidWKT(`SPHEROID["km",6378.137,298.257223563,UNIT["kilometre",1000]]`); err != nil || sph != WGS1984() {
+		t.Errorf("km: got %v, %v", sph, err)
+	}
+	if _, err := ParseSpheroidWKT(`DATUM["x",PRIMEM["Greenwich",0]]`); !errors.Is(err, ErrMissingParam) {
+		t.Errorf("no ellipsoid: got %v", err)
+	}
+}
+
+func TestProjectionWKT(t *testing.T) {
+	sph := GRS1980()
+	prjs := []MapProjection{
+		NewAlbers(sph, 29.5, 45.5, 23, -96),
+		NewTransverseMercator(sph, 49, -2, 0.9996012717, 4e5, -1e5),
+		NewUTM(WGS1984(), 33, false),
+		NewUTM(WGS1984(), 0, true),
+		NewLambertConformalConic(sph, 33, 45, 23, -96, 1e6, 0),
+		NewLambertConformalConic1SP(Clarke1866(), 18, -77, 1, 250000, 150000),
+		NewMercator(sph, 42, 51, 0, 0),
+		NewWebMercator(),
+		NewPolarStereographicA(sph, 90, 0, 0.994, 2e6, 2e6),
+		NewPolarStereographicB(sph, -71, 70, 6e6, 4e6),
+		NewPolarStereographicC(sph, -67, 140, 3e5, 2e5),
+		NewObliqueStereographic(sph, 52.15616055555555, 5.38763888888889, 0.9999079, 155000, 463000),
+		NewAzimuthalEquidistant(sph, Geo(40, -100, 0), 5e5, 5e5),
+		NewGnomonic(sph, Geo(-30, 20, 0), 0, 0),
+		NewLambertAzimuthalEqualArea(sph, Geo(52, 10, 0), 4321000, 3210000),
+		NewEqualEarth(sph, 150),
+		NewMollweide(NewSphere(6371000), -30),
+		NewRobinson(sph, 10),
+		NewWinkelTripel(sph, 0),
+		NewNaturalEarth(sph, -90),
+		NewGrid(NewAlbers(sph, 29.5, 45.5, 23, -96), 1e6, -5e5, UnitUSSurveyFoot, true),
+		NewGrid(NewUTM(WGS1984(), 0, false), 0, 0, UnitKilometre, false),
+		NewGrid(NewEqualEarth(sph, 0), 0, 0, 1, true),
+	}
+	for _, prj := range prjs {
+		for _, ver := range []WKTVersion{WKT2, WKT1} {
+			s, err := FormatWKT(prj, ver)
+			if _, c := prj.(PolarStereographic); c && ver == WKT1 && prj.Params()["lat1"] == -67 {
+				// variant C is absent in WKT1
+				if !errors.Is(err, ErrUnknownProjection) {
+					t.Errorf("stere_c: got %v", err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Errorf("%T: %v", prj, err)
+				continue
+			}
+			prj2, err := ParseWKT(s)
+			if err != nil {
+				t.Errorf("%s: %v", s, err)
+				continue
+			}
+			if reflect.TypeOf(prj2) != reflect.TypeOf(prj) || prj2.Spheroid() != prj.Spheroid() || !reflect.DeepEqual(prj2.Params(), prj.Params()) {
+				t.Errorf("%s: got %T %v", s, prj2, prj2.Params())
+			}
+		}
+	}
+}
+
+func TestParseWKT(t *testing.T) {
+	tests := []struct {
+		wkt string
+		prj MapProjection
+	}{
+		// EPSG:5070
+		{`PROJCRS["NAD83 / Conus Albers",
+    BASEGEOGCRS["NAD83",
+        DATUM["North American Datum 1983",
+            ELLIPSOID["GRS 1980",6378137,298.257222101,
+                LENGTHUNIT["metre",1]]],
+        PRIMEM["Greenwich",0,
+            ANGLEUNIT["degree",0.0174532925199433]],
+        ID["EPSG",4269]],
+    CONVERSION["Conus Albers",
+        METHOD["Albers Equal Area",
+            ID["EPSG",9822]],
+        PARAMETER["Latitude of false origin",23,
+            ANGLEUNIT["degree",0.0174532925199433],
+            ID["EPSG",8821]],
+        PARAMETER["Longitude of false origin",-96,
+            ANGLEUNIT["degree",0.0174532925199433],
+            ID["EPSG",8822]],
+        PARAMETER["Latitude of 1st standard parallel",29.5,
+            ANGLEUNIT["degree",0.0174532925199433],
+            ID["EPSG",8823]],
+        PARAMETER["Latitude of 2nd standard parallel",45.5,
+            ANGLEUNIT["degree",0.0174532925199433],
+            ID["EPSG",8824]],
+        PARAMETER["Easting at false origin",0,
+            LENGTHUNIT["metre",1],
+            ID["EPSG",8826]],
+        PARAMETER["Northing at false origin",0,
+            LENGTHUNIT["metre",1],
+            ID["EPSG",8827]]],
+    CS[Cartesian,2],
+        AXIS["easting (X)",east,
+            ORDER[1],
+            LENGTHUNIT["metre",1]],
+        AXIS["northing (Y)",north,
+            ORDER[2],
+            LENGTHUNIT["metre",1]],
+    USAGE[
+        SCOPE["Data analysis and small scale data presentation for contiguous lower 48 states."],
+        AREA["United States (USA) - CONUS onshore."],
+        BBOX[24.41,-124.79,49.38,-66.91]],
+    ID["EPSG",5070]]`,
+			NewAlbers(GRS1980(), 29.5, 45.5, 23, -96)},
+		// EPSG:32633
+		{`PROJCS["WGS 84 / UTM zone 33N",GEOGCS["WGS 84",DATUM["WGS_1984",SPHEROID["WGS 84",6378137,298.257223563,AUTHORITY["EPSG","7030"]],AUTHORITY["EPSG","6326"]],PRIMEM["Greenwich",0,AUTHORITY["EPSG","8901"]],UNIT["degree",0.0174532925199433,AUTHORITY["EPSG","9122"]],AUTHORITY["EPSG","4326"]],PROJECTION["Transverse_Mercator"],PARAMETER["latitude_of_origin",0],PARAMETER["central_meridian",15],PARAMETER["scale_factor",0.9996],PARAMETER["false_easting",500000],PARAMETER["false_northing",0],UNIT["metre",1,AUTHORITY["EPSG","9001"]],AXIS["Easting",EAST],AXIS["Northing",NORTH],AUTHORITY["EPSG","32633"]]`,
+			NewUTM(WGS1984(), 33, true)},
+		// EPSG:3031, the axes along the meridians
+		{`PROJCRS["WGS 84 / Antarctic Polar Stereographic",BASEGEOGCRS["WGS 84",DATUM["World Geodetic System 1984",ELLIPSOID["WGS 84",6378137,298.257223563,LENGTHUNIT["metre",1]]],PRIMEM["Greenwich",0,ANGLEUNIT["degree",0.0174532925199433]]],CONVERSION["Antarctic Polar Stereographic",METHOD["Polar Stereographic (variant B)",ID["EPSG",9829]],PARAMETER["Latitude of standard parallel",-71,ANGLEUNIT["degree",0.0174532925199433],ID["EPSG",8832]],PARAMETER["Longitude of origin",0,ANGLEUNIT["degree",0.0174532925199433],ID["EPSG",8833]],PARAMETER["False easting",0,LENGTHUNIT["metre",1],ID["EPSG",8806]],PARAMETER["False northing",0,LENGTHUNIT["metre",1],ID["EPSG",8807]]],CS[Cartesian,2],AXIS["easting (E)",north,MERIDIAN[90,ANGLEUNIT["degree",0.0174532925199433]],ORDER[1],LENGTHUNIT["metre",1]],AXIS["northing (N)",north,MERIDIAN[0,ANGLEUNIT["degree",0.0174532925199433]],ORDER[2],LENGTHUNIT["metre",1]]]`,
+			NewPolarStereographicB(WGS1984(), -71, 0, 0, 0)},
+		// WKT1 with Polar_Stereographic variant B and the angles in grads
+		{`PROJCS["x",GEOGCS["x",DATUM["x",SPHEROID["WGS 84",6378137,298.257223563]],PRIMEM["Greenwich",0],UNIT["grad",0.015707963267948967]],PROJECTION["Polar_Stereographic"],PARAMETER["latitude_of_origin",-80],PARAMETER["central_meridian",0],PARAMETER["scale_factor",1],PARAMETER["false_easting",0],PARAMETER["false_northing",0],UNIT["metre",1]]`,
+			NewPolarStereographicB(WGS1984(), -72, 0, 0, 0)},
+		// Mercator (variant A) by the name only
+		{`PROJCRS["x",BASEGEOGCRS["x",DATUM["x",ELLIPSOID["WGS 84",6378137,298.257223563]]],CONVERSION["x",METHOD["Mercator (variant A)"],PARAMETER["Longitude of natural origin",110],PARAMETER["Scale factor at natural origin",0.997],PARAMETER["False easting",3900000],PARAMETER["False northing",900000]]]`,
+			NewMercator(WGS1984(), func() float64 { lat1, _ := mercLat1(WGS1984(), 0.997); return lat1 }(), 110, 3900000, 900000)},
+		{`PROJCS["x",GEOGCS["x",DATUM["x",SPHEROID["x",6371000,0]]],PROJECTION["Robinson"],PARAMETER["longitude_of_center",10]]`,
+			NewRobinson(NewSphere(6371000), 10)},
+		// EPSG:2227, US survey feet
+		{`PROJCRS["NAD83 / California zone 3 (ftUS)",BASEGEOGCRS["NAD83",DATUM["North American Datum 1983",ELLIPSOID["GRS 1980",6378137,298.257222101,LENGTHUNIT["metre",1]]],PRIMEM["Greenwich",0,ANGLEUNIT["degree",0.0174532925199433]]],CONVERSION["SPCS83 California zone 3 (US Survey feet)",METHOD["Lambert Conic Conformal (2SP)",ID["EPSG",9802]],PARAMETER["Latitude of false origin",36.5,ANGLEUNIT["degree",0.0174532925199433],ID["EPSG",8821]],PARAMETER["Longitude of false origin",-120.5,ANGLEUNIT["degree",0.0174532925199433],ID["EPSG",8822]],PARAMETER["Latitude of 1st standard parallel",38.4333333333333,ANGLEUNIT["degree",0.0174532925199433],ID["EPSG",8823]],PARAMETER["Latitude of 2nd standard parallel",37.0666666666667,ANGLEUNIT["degree",0.0174532925199433],ID["EPSG",8824]],PARAMETER["Easting at false origin",6561666.667,LENGTHUNIT["US survey foot",0.304800609601219],ID["EPSG",8826]],PARAMETER["Northing at false origin",1640416.667,LENGTHUNIT["US survey foot",0.304800609601219],ID["EPSG",8827]]],CS[Cartesian,2],AXIS["easting (X)",east,ORDER[1],LENGTHUNIT["US survey foot",0.304800609601219]],AXIS["northing (Y)",north,ORDER[2],LENGTHUNIT["US survey foot",0.304800609601219]]]`,
+			NewGrid(NewLambertConformalConic(GRS1980(), 38.4333333333333, 37.0666666666667, 36.5, -120.5,
+				6561666.667*UnitUSSurveyFoot, 1640416.667*UnitUSSurveyFoot), 0, 0, UnitUSSurveyFoot, false)},
+		// EPSG:2193, northing first
+		{`PROJCRS["NZGD2000 / New Zealand Transverse Mercator 2000",BASEGEOGCRS["NZGD2000",DATUM["New Zealand Geodetic Datum 2000",ELLIPSOID["GRS 1980",6378137,298.257222101,LENGTHUNIT["metre",1]]],PRIMEM["Greenwich",0,ANGLEUNIT["degree",0.0174532925199433]]],CONVERSION["New Zealand Transverse Mercator 2000",METHOD["Transverse Mercator",ID["EPSG",9807]],PARAMETER["Latitude of natural origin",0,ANGLEUNIT["degree",0.0174532925199433],ID["EPSG",8801]],PARAMETER["Longitude of natural origin",173,ANGLEUNIT["degree",0.0174532925199433],ID["EPSG",8802]],PARAMETER["Scale factor at natural origin",0.9996,SCALEUNIT["unity",1],ID["EPSG",8805]],PARAMETER["False easting",1600000,LENGTHUNIT["metre",1],ID["EPSG",8806]],PARAMETER["False northing",10000000,LENGTHUNIT["metre",1],ID["EPSG",8807]]],CS[Cartesian,2],AXIS["northing (N)",north,ORDER[1],LENGTHUNIT["metre",1]],AXIS["easting (E)",east,ORDER[2],LENGTHUNIT["metre",1]]]`,
+			NewGrid(NewTransverseMercator(GRS1980(), 0, 173, 0.9996, 1600000, 10000000), 0, 0, 1, true)},
+		// WKT1 in feet, the false easting of Albers
+		{`PROJCS["x",GEOGCS["x",DATUM["x",SPHEROID["GRS 1980",6378137,298.257222101]]],PROJECTION["Albers_Conic_Equal_Area"],PARAMETER["standard_parallel_1",55],PARAMETER["standard_parallel_2",65],PARAMETER["latitude_of_center",50],PARAMETER["longitude_of_center",-154],PARAMETER["false_easting",1000],PARAMETER["false_northing",-2000],UNIT["foot",0.3048],AXIS["Easting",EAST],AXIS["Northing",NORTH]]`,
+			NewGrid(NewAlbers(GRS1980(), 55, 65, 50, -154), 1000*UnitFoot, -2000*UnitFoot, UnitFoot, false)},
+	}
+	for _, tt := range tests {
+		prj, err := ParseWKT(tt.wkt)
+		if err != nil {
+			t.Errorf("%.40s: %v", tt.wkt, err)
+			continue
+		}
+		if reflect.TypeOf(prj) != reflect.TypeOf(tt.prj) || prj.Spheroid() != tt.prj.Spheroid() {
+			t.Errorf("%.40s: got %T %v", tt.wkt, prj, prj.Spheroid())
+			continue
+		}
+		for k, v := range tt.prj.Params() {
+			if w := prj.Params()[k]; !(math.Abs(w-v) <= 1e-12*math.Abs(v)) {
+				t.Errorf("%.40s: got %v, want %v", tt.wkt, prj.Params(), tt.prj.Params())
+				break
+			}
+		}
+	}
+}
+
+func TestParseWKTErrors(t *testing.T) {
+	const base = `BASEGEOGCRS["x",DATUM["x",ELLIPSOID["GRS 1980",6378137,298.257222101]]]`
+	const tm = `CONVERSION["x",METHOD["Transverse Mercator",ID["EPSG",9807]],PARAMETER["Longitude of natural origin",9]]`
+	tests := []struct {
+		wkt string
+		err error
+		bad string
+	}{
+		{`PROJCRS["x",` + base + `,` + tm, ErrWKTSyntax, ""},
+		{`PROJCRS["x` + base, ErrWKTSyntax, ""},
+		{`PROJCRS["x",` + base + `,` + tm + `]]`, ErrWKTSyntax, "]"},
+		{`GEOGCRS["x",DATUM["x",ELLIPSOID["GRS 1980",6378137,298.257222101]]]`, ErrUnknownProjection, "GEOGCRS"},
+		{`PROJCRS["x",` + base + `]`, ErrMissingParam, "PROJCRS"},
+		{`PROJCRS["x",` + base + `,CONVERSION["x",METHOD["Bonne",ID["EPSG",9827]]]]`, ErrUnknownProjection, "Bonne"},
+		{`PROJCRS["x",` + base + `,CONVERSION["x",METHOD["Albers Equal Area"],PARAMETER["Latitude of 1st standard parallel",30]]]`, ErrMissingParam, "Latitude of 2nd standard parallel"},
+		{`PROJCRS["x",` + base + `,CONVERSION["x",METHOD["Equal Earth"],PARAMETER["Azimuth",30]]]`, ErrUnknownParam, "Azimuth"},
+		{`PROJCRS["x",` + base + `,CONVERSION["x",METHOD["Transverse Mercator"],PARAMETER["Latitude of natural origin",95]]]`, ErrInvalidParam, "Latitude of natural origin"},
+		{`PROJCRS["x",` + base + `,CONVERSION["x",METHOD["Transverse Mercator"],PARAMETER["Scale factor at natural origin",0]]]`, ErrInvalidParam, "Scale factor at natural origin"},
+		{`PROJCRS["x",BASEGEOGCRS["x",DATUM["x",ELLIPSOID["x",6378137,10]]],` + tm + `]`, ErrInvalidParam, "ELLIPSOID"},
+		{`PROJCRS["x",BASEGEOGCRS["x",DATUM["x",ELLIPSOID["GRS 1980",6378137,298.257222101]],PRIMEM["Paris",2.33722917]],` + tm + `]`, ErrInvalidParam, "PRIMEM"},
+		{`PROJCRS["x",` + base + `,CONVERSION["x",METHOD["Mercator (variant A)"],PARAMETER["Latitude of natural origin",10]]]`, ErrInvalidParam, "Latitude of natural origin"},
+		{`PROJCRS["x",` + base + `,` + tm + `,CS[Cartesian,2],AXIS["westing (W)",west],AXIS["northing (N)",north]]`, ErrInvalidParam, "AXIS"},
+		{`PROJCRS["x",` + base + `,` + tm + `,CS[Cartesian,2],AXIS["x",east,LENGTHUNIT["US survey foot",0.304800609601219]],AXIS["y",north,LENGTHUNIT["metre",1]]]`, ErrInvalidParam, "LENGTHUNIT"},
+		{`PROJCS["x",GEOGCS["x",DATUM["x",SPHEROID["x",6378137,298.257222101]]],PROJECTION["Transverse_Mercator"],PARAMETER["central_meridian",9],UNIT["foot",-0.3048]]`, ErrInvalidParam, "UNIT"},
+	}
+	for _, tt := range tests {
+		_, err := ParseWKT(tt.wkt)
+		if !errors.Is(err, tt.err) || !strings.Contains(err.Error(), "`"+tt.bad) {
+			t.Errorf("%s: got %v", tt.wkt, err)
+		}
+	}
+	if _, err := FormatWKT(nil, WKT2); !errors.Is(err, ErrUnknownProjection) {
+		t.Errorf("FormatWKT(nil): got %v", err)
+	}
+}
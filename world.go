@@ -0,0 +1,661 @@
+package geomys
+
+import (
+	"fmt"
+	"github.com/reconditematter/mym"
+	"math"
+)
+
+// worldMaxit -- the maximal number of iterations in the inverses of the world map projections.
+const worldMaxit = 25
+
+// EqualEarth -- Equal Earth map projection, an equal-area pseudocylindrical projection.
+// The authalic latitude is mapped on the authalic sphere of radius Rs.
+//
+// Reference: Šavrič, B., Patterson, T., Jenny, B. The Equal Earth map projection.
+// International Journal of Geographical Information Science 33(3), 454–465 (2019).
+//
+// DOI: https://doi.org/10.1080/13658816.2018.1504949
+type EqualEarth struct {
+	sph Spheroid
+	par map[string]float64
+	r   float64
+}
+
+// Equal Earth polynomial coefficients
+const (
+	eeA1 = 1.340264
+	eeA2 = -0.081106
+	eeA3 = 0.000893
+	eeA4 = 0.003796
+)
+
+// NewEqualEarth -- returns a new Equal Earth map projection based on the spheroid `sph`.
+// This function causes a runtime panic when lon0∉[-180,180].
+//
+// The projection has the following parameters:
+//
+//	lon0 -- longitude of the central meridian
+func NewEqualEarth(sph Spheroid, lon0 float64) EqualEarth {
+	if !(-180 <= lon0 && lon0 <= 180) {
+		panic("geomys.NewEqualEarth: domain error: `lon0`")
+	}
+	par := map[string]float64{"lon0": lon0}
+	return EqualEarth{sph: sph, par: par, r: sph.Rs()}
+}
+
+// Spheroid -- returns the spheroid of the map projection.
+func (prj EqualEarth) Spheroid() Spheroid {
+	if prj.par == nil {
+		panic("geomys.EqualEarth.Spheroid: uninitialized structure")
+	}
+	//
+	return prj.sph
+}
+
+// Params -- returns the parameters of the map projection.
+func (prj EqualEarth) Params() map[string]float64 {
+	if prj.par == nil {
+		panic("geomys.EqualEarth.Params: uninitialized structure")
+	}
+	//
+	par := make(map[string]float64)
+	for k, v := range prj.par {
+		par[k] = v
+	}
+	return par
+}
+
+// Project -- transforms a geographic point from
+// the spheroid into a location on the plane.
+func (prj EqualEarth) Project(p Point) (xy [2]float64) {
+	if prj.par == nil {
+		panic("geomys.EqualEarth.Project: uninitialized structure")
+	}
+	//
+	lat, lon, _ := p.Geo()
+	λ := angNormalize(lon-prj.par["lon0"]) * (math.Pi / 180)
+	sβ, _ := mym.SinCosD(prj.sph.AuxLat(AuthalicLat, lat))
+	θ := math.Asin(math.Sqrt(3) / 2 * sβ)
+	y, dy := eeYf(θ)
+	xy[0] = prj.r * 2 * math.Sqrt(3) * λ * math.Cos(θ) / (3 * dy)
+	xy[1] = prj.r * y
+	return
+}
+
+// Unproject -- transforms a location on the plane into
+// a geographic point on the spheroid. Returns an error wrapping
+// ErrOutOfDomain when `xy` is outside the image of the spheroid.
+func (prj EqualEarth) Unproject(xy [2]float64) (Point, error) {
+	if prj.par == nil {
+		panic("geomys.EqualEarth.Unproject: uninitialized structure")
+	}
+	//
+	x, y := xy[0]/prj.r, xy[1]/prj.r
+	θmax := math.Pi / 3
+	ymax, _ := eeYf(θmax)
+	if !(math.Abs(y) <= ymax*(1+4*mym.Epsilon) && mym.FiniteIs(x)) {
+		return Point{}, fmt.Errorf("geomys.EqualEarth.Unproject: %w: `xy`", ErrOutOfDomain)
+	}
+	// solve y(θ)=y by Newton's method, y(θ) is increasing
+	θ := y / eeA1
+	for i := 0; i < worldMaxit; i++ {
+		f, df := eeYf(θ)
+		dθ := (f - y) / df
+		θ = math.Max(-θmax, math.Min(θmax, θ-dθ))
+		if !(math.Abs(dθ) >= 2*mym.Epsilon) {
+			break
+		}
+	}
+	_, dy := eeYf(θ)
+	sβ := 2 / math.Sqrt(3) * math.Sin(θ)
+	β := math.Asin(math.Max(-1, math.Min(1, sβ))) * (180 / math.Pi)
+	lat := prj.sph.GeoLat(AuthalicLat, β)
+	λ, ok := worldLon(x, 2*math.Sqrt(3)*math.Pi*math.Cos(θ)/(3*dy))
+	if !ok {
+		return Point{}, fmt.Errorf("geomys.EqualEarth.Unproject: %w: `xy`", ErrOutOfDomain)
+	}
+	return Geo(lat, angNormalize(prj.par["lon0"]+λ), 0.0), nil
+}
+
+// eeYf -- computes the Equal Earth ordinate y(θ) on the unit sphere and its derivative.
+func eeYf(θ float64) (y, dy float64) {
+	θ2 := θ * θ
+	θ4 := θ2 * θ2
+	y = θ * (eeA1 + θ2*(eeA2+θ4*(eeA3+θ2*eeA4)))
+	dy = eeA1 + θ2*(3*eeA2+θ4*(7*eeA3+9*eeA4*θ2))
+	return
+}
+
+// Mollweide -- Mollweide map projection, an equal-area pseudocylindrical projection.
+// The authalic latitude is mapped on the authalic sphere of radius Rs.
+//
+// Reference: Snyder, J.P. Map Projections: A Working Manual (1987), p.249.
+type Mollweide struct {
+	sph Spheroid
+	par map[string]float64
+	r   float64
+}
+
+// NewMollweide -- returns a new Mollweide map projection based on the spheroid `sph`.
+// This function causes a runtime panic when lon0∉[-180,180].
+//
+// The projection has the following parameters:
+//
+//	lon0 -- longitude of the central meridian
+func NewMollweide(sph Spheroid, lon0 float64) Mollweide {
+	if !(-180 <= lon0 && lon0 <= 180) {
+		panic("geomys.NewMollweide: domain error: `lon0`")
+	}
+	par := map[string]float64{"lon0": lon0}
+	return Mollweide{sph: sph, par: par, r: sph.Rs()}
+}
+
+// Spheroid -- returns the spheroid of the map projection.
+func (prj Mollweide) Spheroid() Spheroid {
+	if prj.par == nil {
+		panic("geomys.Mollweide.Spheroid: uninitialized structure")
+	}
+	//
+	return prj.sph
+}
+
+// Params -- returns the parameters of the map projection.
+func (prj Mollweide) Params() map[string]float64 {
+	if prj.par == nil {
+		panic("geomys.Mollweide.Params: uninitialized structure")
+	}
+	//
+	par := make(map[string]float64)
+	for k, v := range prj.par {
+		par[k] = v
+	}
+	return par
+}
+
+// Project -- transforms a geographic point from
+// the spheroid into a location on the plane.
+func (prj Mollweide) Project(p Point) (xy [2]float64) {
+	if prj.par == nil {
+		panic("geomys.Mollweide.Project: uninitialized structure")
+	}
+	//
+	lat, lon, _ := p.Geo()
+	λ := angNormalize(lon-prj.par["lon0"]) * (math.Pi / 180)
+	sβ, cβ := mym.SinCosD(prj.sph.AuxLat(AuthalicLat, lat))
+	// solve 2θ+sin(2θ)=π⋅sin(β) for u=π-2|θ|, that is u-sin(u)=π⋅(1-|sin(β)|),
+	// which avoids the slow convergence of Newton's method near the poles
+	d := math.Pi * cβ * cβ / (1 + math.Abs(sβ))
+	u := math.Min(math.Pi, math.Cbrt(6*d))
+	for i := 0; i < worldMaxit && u > 0; i++ {
+		su, cu := math.Sincos(u)
+		du := (u - su - d) / (1 - cu)
+		u = math.Max(0, math.Min(math.Pi, u-du))
+		if !(math.Abs(du) >= 2*mym.Epsilon*u) {
+			break
+		}
+	}
+	sθ, cθ := math.Cos(u/2), math.Sin(u/2)
+	xy[0] = prj.r * 2 * math.Sqrt2 / math.Pi * λ * cθ
+	xy[1] = math.Copysign(prj.r*math.Sqrt2*sθ, sβ)
+	return
+}
+
+// Unproject -- transforms a location on the plane into
+// a geographic point on the spheroid. Returns an error wrapping
+// ErrOutOfDomain when `xy` is outside the image of the spheroid.
+func (prj Mollweide) Unproject(xy [2]float64) (Point, error) {
+	if prj.par == nil {
+		panic("geomys.Mollweide.Unproject: uninitialized structure")
+	}
+	//
+	x, y := xy[0]/prj.r, xy[1]/prj.r/math.Sqrt2
+	if !(math.Abs(y) <= 1+4*mym.Epsilon && mym.FiniteIs(x)) {
+		return Point{}, fmt.Errorf("geomys.Mollweide.Unproject: %w: `xy`", ErrOutOfDomain)
+	}
+	θ := math.Asin(math.Max(-1, math.Min(1, y)))
+	// 1-|sin(β)| = (u-sin(u))/π = 2⋅sin²((90°-|β|)/2), where u=π-2|θ|
+	u := math.Pi - 2*math.Abs(θ)
+	d := (u - math.Sin(u)) / math.Pi
+	β := 90 - 2*math.Asin(math.Min(1, math.Sqrt(d/2)))*(180/math.Pi)
+	lat := prj.sph.GeoLat(AuthalicLat, math.Copysign(β, y))
+	λ, ok := worldLon(x, 2*math.Sqrt2*math.Cos(θ))
+	if !ok {
+		return Point{}, fmt.Errorf("geomys.Mollweide.Unproject: %w: `xy`", ErrOutOfDomain)
+	}
+	return Geo(lat, angNormalize(prj.par["lon0"]+λ), 0.0), nil
+}
+
+// Robinson -- Robinson map projection, a compromise pseudocylindrical projection
+// defined by a table of the lengths of the parallels and their distances from the equator
+// at every 5° of latitude. The table is interpolated by a cubic Hermite spline.
+// The geographic coordinates are treated as spherical coordinates
+// on the sphere of radius equal to the semi-major axis of the spheroid.
+//
+// Reference: Snyder, J.P. Flattening the Earth: Two Thousand Years of Map Projections (1993), p.214.
+type Robinson struct {
+	sph Spheroid
+	par map[string]float64
+}
+
+// Robinson's table: the lengths of the parallels and their distances from the equator
+var robX = [19]float64{
+	1.0000, 0.9986, 0.9954, 0.9900, 0.9822, 0.9730, 0.9600, 0.9427, 0.9216, 0.8962,
+	0.8679, 0.8350, 0.7986, 0.7597, 0.7186, 0.6732, 0.6213, 0.5722, 0.5322,
+}
+var robY = [19]float64{
+	0.0000, 0.0620, 0.1240, 0.1860, 0.2480, 0.3100, 0.3720, 0.4340, 0.4958, 0.5571,
+	0.6176, 0.6769, 0.7346, 0.7903, 0.8435, 0.8936, 0.9394, 0.9761, 1.0000,
+}
+
+const (
+	robFX = 0.8487
+	robFY = 1.3523
+)
+
+// NewRobinson -- returns a new Robinson map projection based on the spheroid `sph`.
+// This function causes a runtime panic when lon0∉[-180,180].
+//
+// The projection has the following parameters:
+//
+//	lon0 -- longitude of the central meridian
+func NewRobinson(sph Spheroid, lon0 float64) Robinson {
+	if !(-180 <= lon0 && lon0 <= 180) {
+		panic("geomys.NewRobinson: domain error: `lon0`")
+	}
+	par := map[string]float64{"lon0": lon0}
+	return Robinson{sph: sph, par: par}
+}
+
+// Spheroid -- returns the spheroid of the map projection.
+func (prj Robinson) Spheroid() Spheroid {
+	if prj.par == nil {
+		panic("geomys.Robinson.Spheroid: uninitialized structure")
+	}
+	//
+	return prj.sph
+}
+
+// Params -- returns the parameters of the map projection.
+func (prj Robinson) Params() map[string]float64 {
+	if prj.par == nil {
+		panic("geomys.Robinson.Params: uninitialized structure")
+	}
+	//
+	par := make(map[string]float64)
+	for k, v := range prj.par {
+		par[k] = v
+	}
+	return par
+}
+
+// Project -- transforms a geographic point from
+// the spheroid into a location on the plane.
+func (prj Robinson) Project(p Point) (xy [2]float64) {
+	if prj.par == nil {
+		panic("geomys.Robinson.Project: uninitialized structure")
+	}
+	//
+	lat, lon, _ := p.Geo()
+	λ := angNormalize(lon-prj.par["lon0"]) * (math.Pi / 180)
+	i, t := robIndex(math.Abs(lat) / 5)
+	X, _ := robSpline(&robX, i, t)
+	Y, _ := robSpline(&robY, i, t)
+	a := prj.sph.A()
+	xy[0] = a * robFX * X * λ
+	xy[1] = math.Copysign(a*robFY*Y, lat)
+	return
+}
+
+// Unproject -- transforms a location on the plane into
+// a geographic point on the spheroid. Returns an error wrapping
+// ErrOutOfDomain when `xy` is outside the image of the spheroid.
+func (prj Robinson) Unproject(xy [2]float64) (Point, error) {
+	if prj.par == nil {
+		panic("geomys.Robinson.Unproject: uninitialized structure")
+	}
+	//
+	a := prj.sph.A()
+	x, Y := xy[0]/(a*robFX), math.Abs(xy[1])/(a*robFY)
+	if !(Y <= 1+4*mym.Epsilon && mym.FiniteIs(x)) {
+		return Point{}, fmt.Errorf("geomys.Robinson.Unproject: %w: `xy`", ErrOutOfDomain)
+	}
+	// locate the interval of the table, then solve Y(t)=Y by Newton's method
+	i := 0
+	for i < len(robY)-2 && robY[i+1] <= Y {
+		i++
+	}
+	t := math.Min(1, (Y-robY[i])/(robY[i+1]-robY[i]))
+	for k := 0; k < worldMaxit; k++ {
+		f, df := robSpline(&robY, i, t)
+		dt := (f - Y) / df
+		t = math.Max(0, math.Min(1, t-dt))
+		if !(math.Abs(dt) >= 2*mym.Epsilon) {
+			break
+		}
+	}
+	lat := math.Copysign(5*(float64(i)+t), xy[1])
+	X, _ := robSpline(&robX, i, t)
+	λ, ok := worldLon(x, math.Pi*X)
+	if !ok {
+		return Point{}, fmt.Errorf("geomys.Robinson.Unproject: %w: `xy`", ErrOutOfDomain)
+	}
+	return Geo(lat, angNormalize(prj.par["lon0"]+λ), 0.0), nil
+}
+
+// robIndex -- splits the table coordinate `s`∈[0,18] into
+// the index of the interval i∈[0,17] and the offset t∈[0,1].
+func robIndex(s float64) (i int, t float64) {
+	i = int(math.Min(17, math.Floor(s)))
+	t = s - float64(i)
+	return
+}
+
+// robSpline -- evaluates the cubic Hermite spline through the table `v`
+// and its derivative with respect to `t` at the offset `t` in the interval `i`.
+// The tangents are the central differences, the table is extended
+// symmetrically (robX) or antisymmetrically (robY) across the equator.
+func robSpline(v *[19]float64, i int, t float64) (f, df float64) {
+	m := func(j int) float64 {
+		switch j {
+		case 0:
+			if v == &robY {
+				return v[1]
+			}
+			return 0
+		case 18:
+			return v[18] - v[17]
+		}
+		return (v[j+1] - v[j-1]) / 2
+	}
+	p0, p1, m0, m1 := v[i], v[i+1], m(i), m(i+1)
+	t2 := t * t
+	t3 := t2 * t
+	f = (2*t3-3*t2+1)*p0 + (t3-2*t2+t)*m0 + (-2*t3+3*t2)*p1 + (t3-t2)*m1
+	df = (6*t2-6*t)*(p0-p1) + (3*t2-4*t+1)*m0 + (3*t2-2*t)*m1
+	return
+}
+
+// WinkelTripel -- Winkel tripel map projection, the arithmetic mean of the equirectangular
+// projection with the standard parallel acos(2/π) and the Aitoff projection.
+// The geographic coordinates are treated as spherical coordinates
+// on the sphere of radius equal to the semi-major axis of the spheroid.
+//
+// Reference: Ipbüker, C., Bildirici, I.Ö. A general algorithm for the inverse transformation
+// of map projections using Jacobian matrices. In: Proceedings of the Third International
+// Symposium Mathematical & Computational Applications, 175–182 (2002).
+type WinkelTripel struct {
+	sph Spheroid
+	par map[string]float64
+}
+
+// NewWinkelTripel -- returns a new Winkel tripel map projection based on the spheroid `sph`.
+// This function causes a runtime panic when lon0∉[-180,180].
+//
+// The projection has the following parameters:
+//
+//	lon0 -- longitude of the central meridian
+func NewWinkelTripel(sph Spheroid, lon0 float64) WinkelTripel {
+	if !(-180 <= lon0 && lon0 <= 180) {
+		panic("geomys.NewWinkelTripel: domain error: `lon0`")
+	}
+	par := map[string]float64{"lon0": lon0}
+	return WinkelTripel{sph: sph, par: par}
+}
+
+// Spheroid -- returns the spheroid of the map projection.
+func (prj WinkelTripel) Spheroid() Spheroid {
+	if prj.par == nil {
+		panic("geomys.WinkelTripel.Spheroid: uninitialized structure")
+	}
+	//
+	return prj.sph
+}
+
+// Params -- returns the parameters of the map projection.
+func (prj WinkelTripel) Params() map[string]float64 {
+	if prj.par == nil {
+		panic("geomys.WinkelTripel.Params: uninitialized structure")
+	}
+	//
+	par := make(map[string]float64)
+	for k, v := range prj.par {
+		par[k] = v
+	}
+	return par
+}
+
+// Project -- transforms a geographic point from
+// the spheroid into a location on the plane.
+func (prj WinkelTripel) Project(p Point) (xy [2]float64) {
+	if prj.par == nil {
+		panic("geomys.WinkelTripel.Project: uninitialized structure")
+	}
+	//
+	lat, lon, _ := p.Geo()
+	λ := angNormalize(lon-prj.par["lon0"]) * (math.Pi / 180)
+	x, y, _, _, _, _ := wintri(lat*(math.Pi/180), λ)
+	a := prj.sph.A()
+	xy[0] = a * x
+	xy[1] = a * y
+	return
+}
+
+// Unproject -- transforms a location on the plane into
+// a geographic point on the spheroid. Returns an error wrapping
+// ErrOutOfDomain when `xy` is outside the image of the spheroid
+// or the iterations do not converge.
+func (prj WinkelTripel) Unproject(xy [2]float64) (Point, error) {
+	if prj.par == nil {
+		panic("geomys.WinkelTripel.Unproject: uninitialized structure")
+	}
+	//
+	a := prj.sph.A()
+	x, y := xy[0]/a, xy[1]/a
+	if !(mym.FiniteIs(x) && mym.FiniteIs(y)) || math.Abs(y) > math.Pi/2*(1+4*mym.Epsilon) {
+		return Point{}, fmt.Errorf("geomys.WinkelTripel.Unproject: %w: `xy`", ErrOutOfDomain)
+	}
+	// solve (x(φ,λ),y(φ,λ))=(x,y) by Newton's method
+	φ := math.Max(-math.Pi/2, math.Min(math.Pi/2, y))
+	λ := math.Max(-math.Pi, math.Min(math.Pi, 2*x/(2/math.Pi+math.Cos(φ))))
+	tol := 2 * mym.Epsilon
+	for i := 0; i < worldMaxit; i++ {
+		fx, fy, xφ, xλ, yφ, yλ := wintri(φ, λ)
+		fx -= x
+		fy -= y
+		det := xφ*yλ - xλ*yφ
+		dφ := (fx*yλ - fy*xλ) / det
+		dλ := (fy*xφ - fx*yφ) / det
+		if !(mym.FiniteIs(dφ) && mym.FiniteIs(dλ)) {
+			break
+		}
+		φ = math.Max(-math.Pi/2, math.Min(math.Pi/2, φ-dφ))
+		λ = math.Max(-math.Pi, math.Min(math.Pi, λ-dλ))
+		if !(math.Abs(dφ) >= tol || math.Abs(dλ) >= tol) {
+			break
+		}
+	}
+	// reject the locations outside the image
+	fx, fy, _, _, _, _ := wintri(φ, λ)
+	if !(math.Hypot(fx-x, fy-y) <= 64*mym.Epsilon*(1+math.Hypot(x, y))) {
+		return Point{}, fmt.Errorf("geomys.WinkelTripel.Unproject: %w: `xy`", ErrOutOfDomain)
+	}
+	lat := φ * (180 / math.Pi)
+	if math.Abs(lat) == 90 {
+		// the poles are mapped to the segments x=λ/π
+		λ = math.Max(-math.Pi, math.Min(math.Pi, math.Pi*x))
+	}
+	return Geo(lat, angNormalize(prj.par["lon0"]+λ*(180/math.Pi)), 0.0), nil
+}
+
+// wintri -- computes the Winkel tripel coordinates on the unit sphere
+// and their partial derivatives for the latitude `φ` and the longitude `λ` (radians).
+func wintri(φ, λ float64) (x, y, xφ, xλ, yφ, yλ float64) {
+	sφ, cφ := math.Sincos(φ)
+	sλ2, cλ2 := math.Sincos(λ / 2)
+	// α is the angular distance from the origin, ca=cos(α), sa=sin(α)
+	ca := cφ * cλ2
+	sa := math.Sqrt(math.Max(0, 1-ca*ca))
+	α := math.Atan2(sa, ca)
+	// E=α/sin(α), dE=dE/d(cos(α))
+	E, dE := 1.0, -1.0/3
+	if sa > 0 {
+		E = α / sa
+		dE = (E*ca - 1) / (sa * sa)
+	}
+	x = (λ*(2/math.Pi) + 2*E*cφ*sλ2) / 2
+	y = (φ + E*sφ) / 2
+	// the partial derivatives of cos(α)
+	caφ := -sφ * cλ2
+	caλ := -cφ * sλ2 / 2
+	xφ = (2*dE*caφ*cφ*sλ2 - 2*E*sφ*sλ2) / 2
+	xλ = (2/math.Pi + 2*dE*caλ*cφ*sλ2 + E*cφ*cλ2) / 2
+	yφ = (1 + dE*caφ*sφ + E*cφ) / 2
+	yλ = dE * caλ * sφ / 2
+	return
+}
+
+// NaturalEarth -- Natural Earth map projection, a compromise pseudocylindrical projection
+// defined by polynomials in the latitude. The geographic coordinates are treated
+// as spherical coordinates on the sphere of radius equal to the semi-major axis of the spheroid.
+//
+// Reference: Šavrič, B., Jenny, B., Patterson, T., Petrovič, D., Hurni, L.
+// A polynomial equation for the Natural Earth projection.
+// Cartography and Geographic Information Science 38(4), 363–372 (2011).
+//
+// DOI: https://doi.org/10.1559/15230406384363
+type NaturalEarth struct {
+	sph Spheroid
+	par map[string]float64
+}
+
+// Natural Earth polynomial coefficients
+const (
+	neA0 = 0.8707
+	neA1 = -0.131979
+	neA2 = -0.013791
+	neA3 = 0.003971
+	neA4 = -0.001529
+	neB0 = 1.007226
+	neB1 = 0.015085
+	neB2 = -0.044475
+	neB3 = 0.028874
+	neB4 = -0.005916
+)
+
+// NewNaturalEarth -- returns a new Natural Earth map projection based on the spheroid `sph`.
+// This function causes a runtime panic when lon0∉[-180,180].
+//
+// The projection has the following parameters:
+//
+//	lon0 -- longitude of the central meridian
+func NewNaturalEarth(sph Spheroid, lon0 float64) NaturalEarth {
+	if !(-180 <= lon0 && lon0 <= 180) {
+		panic("geomys.NewNaturalEarth: domain error: `lon0`")
+	}
+	par := map[string]float64{"lon0": lon0}
+	return NaturalEarth{sph: sph, par: par}
+}
+
+// Spheroid -- returns the spheroid of the map projection.
+func (prj NaturalEarth) Spheroid() Spheroid {
+	if prj.par == nil {
+		panic("geomys.NaturalEarth.Spheroid: uninitialized structure")
+	}
+	//
+	return prj.sph
+}
+
+// Params -- returns the parameters of the map projection.
+func (prj NaturalEarth) Params() map[string]float64 {
+	if prj.par == nil {
+		panic("geomys.NaturalEarth.Params: uninitialized structure")
+	}
+	//
+	par := make(map[string]float64)
+	for k, v := range prj.par {
+		par[k] = v
+	}
+	return par
+}
+
+// Project -- transforms a geographic point from
+// the spheroid into a location on the plane.
+func (prj NaturalEarth) Project(p Point) (xy [2]float64) {
+	if prj.par == nil {
+		panic("geomys.NaturalEarth.Project: uninitialized structure")
+	}
+	//
+	lat, lon, _ := p.Geo()
+	λ := angNormalize(lon-prj.par["lon0"]) * (math.Pi / 180)
+	φ := lat * (math.Pi / 180)
+	y, _ := neYf(φ)
+	a := prj.sph.A()
+	xy[0] = a * λ * neLf(φ)
+	xy[1] = a * y
+	return
+}
+
+// Unproject -- transforms a location on the plane into
+// a geographic point on the spheroid. Returns an error wrapping
+// ErrOutOfDomain when `xy` is outside the image of the spheroid.
+func (prj NaturalEarth) Unproject(xy [2]float64) (Point, error) {
+	if prj.par == nil {
+		panic("geomys.NaturalEarth.Unproject: uninitialized structure")
+	}
+	//
+	a := prj.sph.A()
+	x, y := xy[0]/a, xy[1]/a
+	ymax, _ := neYf(math.Pi / 2)
+	if !(math.Abs(y) <= ymax*(1+4*mym.Epsilon) && mym.FiniteIs(x)) {
+		return Point{}, fmt.Errorf("geomys.NaturalEarth.Unproject: %w: `xy`", ErrOutOfDomain)
+	}
+	// solve y(φ)=y by Newton's method, y(φ) is increasing
+	φ := y / neB0
+	for i := 0; i < worldMaxit; i++ {
+		f, df := neYf(φ)
+		dφ := (f - y) / df
+		φ = math.Max(-math.Pi/2, math.Min(math.Pi/2, φ-dφ))
+		if !(math.Abs(dφ) >= 2*mym.Epsilon) {
+			break
+		}
+	}
+	λ, ok := worldLon(x, math.Pi*neLf(φ))
+	if !ok {
+		return Point{}, fmt.Errorf("geomys.NaturalEarth.Unproject: %w: `xy`", ErrOutOfDomain)
+	}
+	return Geo(φ*(180/math.Pi), angNormalize(prj.par["lon0"]+λ), 0.0), nil
+}
+
+// neLf -- computes the Natural Earth length of the parallel `φ` on the unit sphere.
+func neLf(φ float64) float64 {
+	φ2 := φ * φ
+	φ4 := φ2 * φ2
+	return neA0 + φ2*(neA1+φ2*(neA2+φ4*φ2*(neA3+φ2*neA4)))
+}
+
+// neYf -- computes the Natural Earth ordinate y(φ) on the unit sphere and its derivative.
+func neYf(φ float64) (y, dy float64) {
+	φ2 := φ * φ
+	φ4 := φ2 * φ2
+	y = φ * (neB0 + φ2*(neB1+φ4*(neB2+φ2*(neB3+φ2*neB4))))
+	dy = neB0 + φ2*(3*neB1+φ4*(7*neB2+φ2*(9*neB3+11*neB4*φ2)))
+	return
+}
+
+// worldLon -- computes the longitude λ∈[-180,180] (degrees) relative to the central meridian
+// of the pseudocylindrical projection on the unit sphere, where `x` is the abscissa and `xmax`
+// is the abscissa of the antimeridian on the same parallel. Allows for the round-off
+// at the antimeridian and returns false when `x` is outside the parallel.
+func worldLon(x, xmax float64) (λ float64, ok bool) {
+	if !(math.Abs(x) <= xmax) {
+		if !(math.Abs(x) <= xmax+16*mym.Epsilon) {
+			return 0, false
+		}
+		return math.Copysign(180, x), true
+	}
+	if xmax == 0 {
+		return 0, true
+	}
+	return 180 * x / xmax, true
+}
@@ -0,0 +1,113 @@
+package geomys
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestWorldKnown(t *testing.T) {
+	sph := WGS1984()
+	a, r := sph.A(), sph.Rs()
+	tests := []struct {
+		prj  MapProjection
+		p    Point
+		x, y float64
+	}{
+		// the ends of the equator and the poles
+		{NewMollweide(sph, 0), Geo(0, 180, 0), 2 * math.Sqrt2 * r, 0},
+		{NewMollweide(sph, 0), Geo(90, 0, 0), 0, math.Sqrt2 * r},
+		{NewWinkelTripel(sph, 0), Geo(0, 180, 0), a * (1 + math.Pi/2), 0},
+		{NewWinkelTripel(sph, 0), Geo(90, 180, 0), a, a * math.Pi / 2},
+		// the extent of EPSG:8857, WGS 84 / Equal Earth Greenwich
+		{NewEqualEarth(sph, 0), Geo(0, 180, 0), 17243959.06, 0},
+		{NewEqualEarth(sph, 0), Geo(90, 0, 0), 0, 8392927.60},
+		{NewNaturalEarth(sph, 0), Geo(0, 180, 0), a * math.Pi * 0.8707, 0},
+		// the nodes of Robinson's table
+		{NewRobinson(sph, 0), Geo(45, 30, 0), a * 0.8487 * 0.8962 * math.Pi / 6, a * 1.3523 * 0.5571},
+		{NewRobinson(sph, 0), Geo(-90, -180, 0), -a * 0.8487 * 0.5322 * math.Pi, -a * 1.3523},
+	}
+	for _, tt := range tests {
+		xy := tt.prj.Project(tt.p)
+		if math.Abs(xy[0]-tt.x) > 0.01 || math.Abs(xy[1]-tt.y) > 0.01 {
+			t.Errorf("%T.Project: got %v, want [%v %v]", tt.prj, xy, tt.x, tt.y)
+		}
+	}
+}
+
+func TestWorldEqualArea(t *testing.T) {
+	sph := WGS1984()
+	prjs := []MapProjection{NewEqualEarth(sph, 0), NewMollweide(sph, 0)}
+	e2, a := sph.E2(), sph.A()
+	const h = 1e-4
+	for _, prj := range prjs {
+		for lat := -85.0; lat <= 85; lat += 10 {
+			for _, lon := range []float64{-170, -60, 0, 45, 120} {
+				// the Jacobian determinant over the area element of the spheroid
+				xφ0 := prj.Project(Geo(lat-h, lon, 0))
+				xφ1 := prj.Project(Geo(lat+h, lon, 0))
+				xλ0 := prj.Project(Geo(lat, lon-h, 0))
+				xλ1 := prj.Project(Geo(lat, lon+h, 0))
+				det := ((xφ1[0]-xφ0[0])*(xλ1[1]-xλ0[1]) - (xφ1[1]-xφ0[1])*(xλ1[0]-xλ0[0])) / (4 * h * h)
+				s, c := math.Sincos(lat * math.Pi / 180)
+				w := 1 - e2*s*s
+				dA := a * a * (1 - e2) / (w * w) * c * (math.Pi / 180) * (math.Pi / 180)
+				if math.Abs(math.Abs(det)/dA-1) > 1e-7 {
+					t.Errorf("%T (%v,%v): areal scale %v", prj, lat, lon, math.Abs(det)/dA)
+				}
+			}
+		}
+	}
+}
+
+func TestWorldRoundTrip(t *testing.T) {
+	var prjs []InvertibleProjection
+	for _, sph := range []Spheroid{WGS1984(), Clarke1866()} {
+		for _, lon0 := range []float64{0, -96, 150} {
+			prjs = append(prjs,
+				NewEqualEarth(sph, lon0),
+				NewMollweide(sph, lon0),
+				NewRobinson(sph, lon0),
+				NewWinkelTripel(sph, lon0),
+				NewNaturalEarth(sph, lon0),
+			)
+		}
+	}
+	for _, prj := range prjs {
+		for lat := -90.0; lat <= 90; lat += 2.5 {
+			for lon := -180.0; lon <= 180; lon += 7.5 {
+				p, err := prj.Unproject(prj.Project(Geo(lat, lon, 0)))
+				if err != nil {
+					t.Fatalf("%T %v (%v,%v): %v", prj, prj.Params(), lat, lon, err)
+				}
+				lat2, lon2, _ := p.Geo()
+				dlon, _ := angDiff(lon, lon2)
+				if math.Abs(lat) == 90 {
+					dlon = 0
+				}
+				if math.Abs(lat2-lat) > 1e-9 || math.Abs(dlon) > 1e-9 {
+					t.Errorf("%T %v (%v,%v): got (%v,%v)", prj, prj.Params(), lat, lon, lat2, lon2)
+				}
+			}
+		}
+	}
+}
+
+func TestWorldOutOfDomain(t *testing.T) {
+	sph := WGS1984()
+	a := sph.A()
+	prjs := []InvertibleProjection{
+		NewEqualEarth(sph, 0),
+		NewMollweide(sph, 0),
+		NewRobinson(sph, 0),
+		NewWinkelTripel(sph, 0),
+		NewNaturalEarth(sph, 0),
+	}
+	for _, prj := range prjs {
+		for _, xy := range [][2]float64{{0, 2 * a}, {4 * a, 0}, {3 * a, 1.2 * a}, {math.NaN(), 0}} {
+			if _, err := prj.Unproject(xy); !errors.Is(err, ErrOutOfDomain) {
+				t.Errorf("%T.Unproject(%v): got %v", prj, xy, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package geomys
+
+import (
+	"github.com/reconditematter/mym"
+	"math"
+)
+
+// CrossTrack -- a solver of the cross-track and the along-track distances
+// from a point to a geodesic segment for a spheroidal model of the Earth.
+type CrossTrack struct {
+	g Geodesic
+}
+
+// NewCrossTrack -- returns a cross-track solver for the spheroid `sph`.
+func NewCrossTrack(sph Spheroid) CrossTrack {
+	return CrossTrack{NewGeodesic(sph)}
+}
+
+// Spheroid -- returns the spheroid of `c`.
+func (c CrossTrack) Spheroid() Spheroid {
+	return c.g.sph
+}
+
+// Track -- the position of a point relative to a geodesic segment.
+type Track struct {
+	Foot   Point   // the foot of the perpendicular from the point to the geodesic through the segment
+	XTrack float64 // the signed cross-track distance (meters), positive when the point is to the right of the segment
+	ATrack float64 // the signed along-track distance (meters) from the start of the segment to Foot
+	Length float64 // the length (meters) of the segment
+	Inside bool    // whether Foot lies within the segment
+	Dist   float64 // the shortest distance (meters) from the point to the segment
+}
+
+const (
+	trackMaxit = 100
+	trackTol   = 16 * mym.Epsilon
+)
+
+// Solve -- computes the position of the point `p` relative to the geodesic segment from `p1` to `p2`.
+// The foot of the perpendicular is found on the whole geodesic through `p1` and `p2`;
+// when the foot falls outside the segment, the shortest distance to the segment
+// is the distance to the nearest end point.
+func (c CrossTrack) Solve(p, p1, p2 Point) Track {
+	g := c.g
+	R := g.sph.Rs()
+	L, α1, _ := g.Inverse(p1, p2)
+	l := NewGeodesicLine(g, p1, α1)
+	// start at the middle of the segment, so that the foot closest to the segment is found
+	s := L / 2
+	for i := 0; i < trackMaxit; i++ {
+		q, β := l.Position(s)
+		d, γ, _ := g.Inverse(q, p)
+		if d/R < trackTol {
+			break
+		}
+		// the foot of the perpendicular on the sphere: tan(ds) = tan(d)⋅cos(δ)
+		_, cδ := mym.SinCosD(γ - β)
+		ds := math.Atan2(math.Sin(d/R)*cδ, math.Cos(d/R))
+		s += R * ds
+		if math.Abs(ds) < trackTol {
+			break
+		}
+	}
+	//
+	foot, β := l.Position(s)
+	d, γ, _ := g.Inverse(foot, p)
+	sδ, _ := mym.SinCosD(γ - β)
+	tr := Track{Foot: foot, XTrack: math.Copysign(d, sδ), ATrack: s, Length: L}
+	// the along-track distance is accurate to about trackTol⋅R
+	tol := 2 * trackTol * R
+	tr.Inside = -tol <= s && s <= L+tol
+	if tr.Inside {
+		tr.Dist = d
+	} else {
+		d1, _, _ := g.Inverse(p, p1)
+		d2, _, _ := g.Inverse(p, p2)
+		tr.Dist = math.Min(d1, d2)
+	}
+	return tr
+}
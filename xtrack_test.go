@@ -0,0 +1,101 @@
+package geomys
+
+import (
+	"math"
+	"testing"
+)
+
+// trackCheck -- checks that the cross-track distance is the distance from `p` to the foot,
+// that the foot is the foot of the perpendicular, and that the along-track distances add up.
+func trackCheck(t *testing.T, g Geodesic, p, p1, p2 Point, tr Track) {
+	t.Helper()
+	d, γ, _ := g.Inverse(tr.Foot, p)
+	if math.Abs(math.Abs(tr.XTrack)-d) > 1e-6 {
+		t.Errorf("%v %v-%v: XTrack=%v, the distance to the foot is %v", p, p1, p2, tr.XTrack, d)
+	}
+	if d > 1 {
+		_, α1, _ := g.Inverse(p1, p2)
+		_, β := NewGeodesicLine(g, p1, α1).Position(tr.ATrack)
+		if δ, _ := angDiff(β, γ); math.Abs(math.Abs(δ)-90) > 1e-7 || math.Signbit(δ) != math.Signbit(tr.XTrack) {
+			t.Errorf("%v %v-%v: the angle at the foot is %v", p, p1, p2, δ)
+		}
+	}
+	// the distances along the geodesic are the shortest distances within a half of the geodesic
+	d1, _, _ := g.Inverse(p1, tr.Foot)
+	d2, _, _ := g.Inverse(tr.Foot, p2)
+	if math.Abs(tr.ATrack) < 1.5e7 && math.Abs(d1-math.Abs(tr.ATrack)) > 1e-6 ||
+		math.Abs(tr.Length-tr.ATrack) < 1.5e7 && math.Abs(d2-math.Abs(tr.Length-tr.ATrack)) > 1e-6 {
+		t.Errorf("%v %v-%v: ATrack=%v Length=%v, the distances are %v %v", p, p1, p2, tr.ATrack, tr.Length, d1, d2)
+	}
+	if L, _, _ := g.Inverse(p1, p2); tr.Length != L {
+		t.Errorf("%v %v-%v: Length=%v, want %v", p, p1, p2, tr.Length, L)
+	}
+}
+
+func TestCrossTrackEquator(t *testing.T) {
+	sph := WGS1984()
+	c, g := NewCrossTrack(sph), NewGeodesic(sph)
+	p1, p2 := Geo(0, 0, 0), Geo(0, 20, 0)
+	a := sph.A() * math.Pi / 180
+	// the meridian distance from the equator
+	m := func(lat float64) float64 {
+		s, _, _ := g.Inverse(Geo(0, 0, 0), Geo(lat, 0, 0))
+		return s
+	}
+	cases := []struct {
+		p              Point
+		xtrack, atrack float64
+		inside         bool
+	}{
+		// the meridians are perpendicular to the equator
+		{Geo(5, 10, 0), -m(5), 10 * a, true},
+		{Geo(-30, 15, 0), m(30), 15 * a, true},
+		// before p1 and after p2
+		{Geo(5, -10, 0), -m(5), -10 * a, false},
+		{Geo(-5, 30, 0), m(5), 30 * a, false},
+	}
+	for _, cc := range cases {
+		tr := c.Solve(cc.p, p1, p2)
+		trackCheck(t, g, cc.p, p1, p2, tr)
+		if math.Abs(tr.XTrack-cc.xtrack) > 1e-6 || math.Abs(tr.ATrack-cc.atrack) > 1e-6 || tr.Inside != cc.inside {
+			t.Errorf("%v: got %+v", cc.p, tr)
+		}
+		dist := math.Abs(tr.XTrack)
+		if !tr.Inside {
+			d1, _, _ := g.Inverse(cc.p, p1)
+			d2, _, _ := g.Inverse(cc.p, p2)
+			dist = math.Min(d1, d2)
+		}
+		if math.Abs(tr.Dist-dist) > 1e-6 {
+			t.Errorf("%v: Dist=%v, want %v", cc.p, tr.Dist, dist)
+		}
+	}
+}
+
+func TestCrossTrack(t *testing.T) {
+	for _, sph := range []Spheroid{WGS1984(), SRMmax()} {
+		c, g := NewCrossTrack(sph), NewGeodesic(sph)
+		segs := [][2]Point{
+			{Geo(40.6, -73.8, 0), Geo(51.5, -0.1, 0)},
+			{Geo(-33.9, 151.2, 0), Geo(-37.8, 145, 0)},
+			// the segments longer than 90°
+			{Geo(10, 0, 0), Geo(-20, 130, 0)},
+			{Geo(60, -150, 0), Geo(-70, 10, 0)},
+		}
+		for _, seg := range segs {
+			p1, p2 := seg[0], seg[1]
+			for _, p := range []Point{Geo(45, -40, 0), Geo(-30, 150, 0), Geo(0, 60, 0), Geo(80, 100, 0)} {
+				trackCheck(t, g, p, p1, p2, c.Solve(p, p1, p2))
+			}
+			// the points on the segment
+			L, α1, _ := g.Inverse(p1, p2)
+			for _, s := range []float64{0, L / 3, L} {
+				p, _ := NewGeodesicLine(g, p1, α1).Position(s)
+				tr := c.Solve(p, p1, p2)
+				if math.Abs(tr.XTrack) > 1e-6 || math.Abs(tr.ATrack-s) > 1e-6 || !tr.Inside || tr.Dist > 1e-6 {
+					t.Errorf("%v-%v s=%v: got %+v", p1, p2, s, tr)
+				}
+			}
+		}
+	}
+}